@@ -1,7 +1,7 @@
 package main
 
 import (
-	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"bytes"
 	"fmt"
@@ -12,29 +12,31 @@ import (
 	"os"
 )
 
-func calculateMD5(filePath string) (string, error) {
+// calculateDigest returns the SHA-256 digest of the file tagged with its
+// algorithm, in the form hyper-updates stores it.
+func calculateDigest(filePath string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return "", err
 	}
 	defer file.Close()
 
-	hash := md5.New()
+	hash := sha256.New()
 	if _, err := io.Copy(hash, file); err != nil {
 		return "", err
 	}
 
 	hashInBytes := hash.Sum(nil)
-	md5Hash := hex.EncodeToString(hashInBytes)
+	digest := "sha256:" + hex.EncodeToString(hashInBytes)
 
-	return md5Hash, nil
+	return digest, nil
 }
 
 func setHash(){
 	
 	
 		filePath := "../.pio/build/nodemcuv2/firmware.bin"
-		hash, err := calculateMD5(filePath)
+		hash, err := calculateDigest(filePath)
 		url := "http://192.168.0.6/ota/start?mode=fr&hash=" + hash
 	
 		// Create the request
@@ -54,11 +54,16 @@ void ElegantOTAClass::begin(ELEGANTOTA_WEBSERVER *server, const char * username,
         }
       }
 
-      // Get file MD5 hash from arg
+      // Filesystem images are installed ahead of the app, only a new app
+      // reboots the device
+      _mode = mode;
+
+      // Get file hash from arg, tagged digests ("sha256:<hex>") are verified
+      // by hyper-updates, only legacy MD5 hashes are checked by Update
       if (request->hasParam("hash")) {
         String hash = request->getParam("hash")->value();
-        ELEGANTOTA_DEBUG_MSG(String("MD5: "+hash+"\n").c_str());
-        if (!Update.setMD5(hash.c_str())) {
+        ELEGANTOTA_DEBUG_MSG(String("Hash: "+hash+"\n").c_str());
+        if (hash.indexOf(':') < 0 && !Update.setMD5(hash.c_str())) {
           ELEGANTOTA_DEBUG_MSG("ERROR: MD5 hash not valid\n");
           return request->send(400, "text/plain", "MD5 parameter invalid");
         }
@@ -123,11 +128,16 @@ void ElegantOTAClass::begin(ELEGANTOTA_WEBSERVER *server, const char * username,
       }
       
      
-      // Get file MD5 hash from arg
+      // Filesystem images are installed ahead of the app, only a new app
+      // reboots the device
+      _mode = mode;
+
+      // Get file hash from arg, tagged digests ("sha256:<hex>") are verified
+      // by hyper-updates, only legacy MD5 hashes are checked by Update
       if (_server->hasArg("hash")) {
         String hash = _server->arg("hash");
-        ELEGANTOTA_DEBUG_MSG(String("MD5: "+hash+"\n").c_str());
-        if (!Update.setMD5(hash.c_str())) {
+        ELEGANTOTA_DEBUG_MSG(String("Hash: "+hash+"\n").c_str());
+        if (hash.indexOf(':') < 0 && !Update.setMD5(hash.c_str())) {
           ELEGANTOTA_DEBUG_MSG("ERROR: MD5 hash not valid\n");
           return _server->send(400, "text/plain", "MD5 parameter invalid");
         }
@@ -146,7 +156,7 @@ void ElegantOTAClass::begin(ELEGANTOTA_WEBSERVER *server, const char * username,
           String checkHashEndpoint = "http://" + clientIP + ":8080" + "/check-hash?transactionid=" + _server->arg("txid") + "&hash=" + _server->arg("hash");
           Serial.println(checkHashEndpoint);
 
-           // fetch firm md5 hash from blockchain / hyper-updates
+           // check the firmware hash against hyper-updates
           HTTPClient http;
           WiFiClient wifiClient;  // create a WiFiClient object
           http.begin(wifiClient, checkHashEndpoint);
@@ -248,7 +258,7 @@ void ElegantOTAClass::begin(ELEGANTOTA_WEBSERVER *server, const char * username,
         request->send(response);
         // Set reboot flag
         if (!Update.hasError()) {
-          if (_auto_reboot) {
+          if (_auto_reboot && _mode == OTA_MODE_FIRMWARE) {
             _reboot_request_millis = millis();
             _reboot = true;
           }
@@ -301,7 +311,7 @@ void ElegantOTAClass::begin(ELEGANTOTA_WEBSERVER *server, const char * username,
       _server->send((Update.hasError()) ? 400 : 200, "text/plain", (Update.hasError()) ? _update_error_str.c_str() : "OK");
       // Set reboot flag
       if (!Update.hasError()) {
-        if (_auto_reboot) {
+        if (_auto_reboot && _mode == OTA_MODE_FIRMWARE) {
           _reboot_request_millis = millis();
           _reboot = true;
         }
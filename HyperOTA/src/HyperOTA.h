@@ -123,6 +123,7 @@ class ElegantOTAClass{
     char _password[64];
 
     bool _auto_reboot = true;
+    OTA_Mode _mode = OTA_MODE_FIRMWARE;
     bool _reboot = false;
     unsigned long _reboot_request_millis = 0;
 
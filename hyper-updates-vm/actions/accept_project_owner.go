@@ -0,0 +1,132 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*AcceptProjectOwner)(nil)
+
+// AcceptProjectOwner completes a transfer started with [ProposeProjectOwner],
+// it must be sent by the proposed owner.
+type AcceptProjectOwner struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+}
+
+func (*AcceptProjectOwner) GetTypeID() uint8 {
+	return acceptProjectOwnerID
+}
+
+func (a *AcceptProjectOwner) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(a.Project)),
+		string(storage.LegacyProjectKey(a.Project)),
+		string(storage.MaintainersKey(a.Project)),
+		string(storage.PendingOwnerKey(a.Project)),
+		string(storage.ApprovalThresholdKey(a.Project)),
+	}
+}
+
+func (*AcceptProjectOwner) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.PendingOwnerChunks, storage.ThresholdChunks}
+}
+
+func (*AcceptProjectOwner) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *AcceptProjectOwner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	exists, _, err := storage.GetProject(ctx, mu, a.Project)
+	if err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, AcceptProjectOwnerComputeUnits, OutputProjectNotFound, nil, nil
+	}
+	exists, pending, err := storage.GetPendingOwner(ctx, mu, a.Project)
+	if err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, AcceptProjectOwnerComputeUnits, OutputNoPendingOwner, nil, nil
+	}
+	if pending != auth.Actor() {
+		return false, AcceptProjectOwnerComputeUnits, OutputNotPendingOwner, nil, nil
+	}
+
+	// The owner holds every role, so drop any maintainer entry it had. The
+	// new owner can't approve its own updates, the approvers left must still
+	// reach the threshold.
+	maintainers, err := storage.GetMaintainers(ctx, mu, a.Project)
+	if err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	threshold, err := storage.GetApprovalThreshold(ctx, mu, a.Project)
+	if err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if checkApprovers(maintainers, pending, threshold) != nil {
+		return false, AcceptProjectOwnerComputeUnits, OutputOwnerNeededAsApprover, nil, nil
+	}
+	for i := range maintainers {
+		if maintainers[i].Address == pending {
+			maintainers = append(maintainers[:i], maintainers[i+1:]...)
+			if err := storage.SetMaintainers(ctx, mu, a.Project, maintainers); err != nil {
+				return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+			}
+			break
+		}
+	}
+
+	if err := storage.SetProjectOwner(ctx, mu, a.Project, pending); err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.DeletePendingOwner(ctx, mu, a.Project); err != nil {
+		return false, AcceptProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, AcceptProjectOwnerComputeUnits, nil, nil, nil
+}
+
+func (*AcceptProjectOwner) MaxComputeUnits(chain.Rules) uint64 {
+	return AcceptProjectOwnerComputeUnits
+}
+
+func (*AcceptProjectOwner) Size() int {
+	return consts.IDLen
+}
+
+func (a *AcceptProjectOwner) Marshal(p *codec.Packer) {
+	p.PackID(a.Project)
+}
+
+func UnmarshalAcceptProjectOwner(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var accept AcceptProjectOwner
+	p.UnpackID(true, &accept.Project)
+	return &accept, p.Err()
+}
+
+func (*AcceptProjectOwner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
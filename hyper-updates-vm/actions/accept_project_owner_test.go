@@ -0,0 +1,92 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func TestProjectOwnerTransferKeepsApprovers(t *testing.T) {
+	owner := testAddress()
+	approver := testAddress()
+	other := testAddress()
+
+	tests := []struct {
+		name      string
+		newOwner  codec.Address
+		threshold uint8
+		output    []byte
+	}{
+		{name: "outsider", newOwner: testAddress(), threshold: 2},
+		{name: "approver below threshold", newOwner: approver, threshold: 1},
+		{name: "approver needed", newOwner: approver, threshold: 2, output: OutputOwnerNeededAsApprover},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		mu := testState{}
+		project := setTestProject(
+			t,
+			mu,
+			owner,
+			storage.Maintainer{Address: approver, Roles: RoleApprove},
+			storage.Maintainer{Address: other, Roles: RoleApprove},
+		)
+		if err := storage.SetApprovalThreshold(ctx, mu, project, tt.threshold); err != nil {
+			t.Fatal(err)
+		}
+
+		propose := &ProposeProjectOwner{Project: project, NewOwner: tt.newOwner}
+		success, _, output, _, err := propose.Execute(ctx, nil, mu, 1, testAuth{actor: owner}, ids.Empty, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if success != (tt.output == nil) || !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: propose = %t, %q, want %q", tt.name, success, output, tt.output)
+		}
+
+		// Maintainers may change between the proposal and its acceptance
+		if err := storage.SetPendingOwner(ctx, mu, project, tt.newOwner); err != nil {
+			t.Fatal(err)
+		}
+		accept := &AcceptProjectOwner{Project: project}
+		success, _, output, _, err = accept.Execute(ctx, nil, mu, 2, testAuth{actor: tt.newOwner}, ids.Empty, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if success != (tt.output == nil) || !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: accept = %t, %q, want %q", tt.name, success, output, tt.output)
+			continue
+		}
+
+		_, data, err := storage.GetProject(ctx, mu, project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		maintainers, err := storage.GetMaintainers(ctx, mu, project)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !success {
+			if data.ProjectOwner != owner || len(maintainers) != 2 {
+				t.Errorf("%s: rejected transfer changed the project", tt.name)
+			}
+			continue
+		}
+		if data.ProjectOwner != tt.newOwner {
+			t.Errorf("%s: owner = %s, want %s", tt.name, data.ProjectOwner, tt.newOwner)
+		}
+		for _, m := range maintainers {
+			if m.Address == tt.newOwner {
+				t.Errorf("%s: new owner is still a maintainer", tt.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*AddImportSource)(nil)
+
+// AddImportSource trusts a project on another hyper-updates chain, updates it
+// exports can then be imported into the project with [ImportUpdate].
+type AddImportSource struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// SourceChainID is the chain the source project lives on.
+	SourceChainID ids.ID `json:"source_chain_id"`
+
+	// SourceProject is the [TxID] that created the project on the source
+	// chain.
+	SourceProject ids.ID `json:"source_project_id"`
+}
+
+func (*AddImportSource) GetTypeID() uint8 {
+	return addImportSourceID
+}
+
+func (a *AddImportSource) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(a.Project)),
+		string(storage.LegacyProjectKey(a.Project)),
+		string(storage.ImportSourcesKey(a.Project)),
+	}
+}
+
+func (*AddImportSource) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.ImportSourcesChunks}
+}
+
+func (*AddImportSource) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *AddImportSource) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if a.SourceChainID == ids.Empty || a.SourceChainID == r.ChainID() || a.SourceProject == ids.Empty {
+		return false, AddImportSourceComputeUnits, OutputImportSourceInvalid, nil, nil
+	}
+	// Only the owner may decide which chains can publish into the project
+	if output := authorizeProject(ctx, mu, a.Project, auth.Actor(), 0); output != nil {
+		return false, AddImportSourceComputeUnits, output, nil, nil
+	}
+	sources, err := storage.GetImportSources(ctx, mu, a.Project)
+	if err != nil {
+		return false, AddImportSourceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	source := storage.ImportSource{SourceChainID: a.SourceChainID, SourceProject: a.SourceProject}
+	for _, s := range sources {
+		if s == source {
+			return false, AddImportSourceComputeUnits, OutputImportSourceExists, nil, nil
+		}
+	}
+	if len(sources) >= storage.MaxProjectImportSources {
+		return false, AddImportSourceComputeUnits, OutputTooManyImportSources, nil, nil
+	}
+	if err := storage.SetImportSources(ctx, mu, a.Project, append(sources, source)); err != nil {
+		return false, AddImportSourceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, AddImportSourceComputeUnits, nil, nil, nil
+}
+
+func (*AddImportSource) MaxComputeUnits(chain.Rules) uint64 {
+	return AddImportSourceComputeUnits
+}
+
+func (*AddImportSource) Size() int {
+	return consts.IDLen * 3
+}
+
+func (a *AddImportSource) Marshal(p *codec.Packer) {
+	p.PackID(a.Project)
+	p.PackID(a.SourceChainID)
+	p.PackID(a.SourceProject)
+}
+
+func UnmarshalAddImportSource(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var add AddImportSource
+	p.UnpackID(true, &add.Project)
+	p.UnpackID(true, &add.SourceChainID)
+	p.UnpackID(true, &add.SourceProject)
+	return &add, p.Err()
+}
+
+func (*AddImportSource) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
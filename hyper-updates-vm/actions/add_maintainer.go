@@ -0,0 +1,130 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*AddMaintainer)(nil)
+
+type AddMaintainer struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// Maintainer is the address being granted [Roles].
+	Maintainer codec.Address `json:"maintainer"`
+
+	// Roles replaces any roles [Maintainer] already holds on [Project].
+	Roles uint8 `json:"roles"`
+}
+
+func (*AddMaintainer) GetTypeID() uint8 {
+	return addMaintainerID
+}
+
+func (a *AddMaintainer) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(a.Project)),
+		string(storage.LegacyProjectKey(a.Project)),
+		string(storage.MaintainersKey(a.Project)),
+		string(storage.ApprovalThresholdKey(a.Project)),
+	}
+}
+
+func (*AddMaintainer) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.ThresholdChunks}
+}
+
+func (*AddMaintainer) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *AddMaintainer) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if a.Roles == 0 || a.Roles&^AllRoles != 0 {
+		return false, AddMaintainerComputeUnits, OutputMaintainerRolesInvalid, nil, nil
+	}
+	// Only the owner may change who maintains a project
+	if output := authorizeProject(ctx, mu, a.Project, auth.Actor(), 0); output != nil {
+		return false, AddMaintainerComputeUnits, output, nil, nil
+	}
+	if a.Maintainer == auth.Actor() {
+		return false, AddMaintainerComputeUnits, OutputMaintainerIsOwner, nil, nil
+	}
+	maintainers, err := storage.GetMaintainers(ctx, mu, a.Project)
+	if err != nil {
+		return false, AddMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	found := false
+	for i := range maintainers {
+		if maintainers[i].Address == a.Maintainer {
+			maintainers[i].Roles = a.Roles
+			found = true
+			break
+		}
+	}
+	if !found {
+		if len(maintainers) >= storage.MaxProjectMaintainers {
+			return false, AddMaintainerComputeUnits, OutputTooManyMaintainers, nil, nil
+		}
+		maintainers = append(maintainers, storage.Maintainer{Address: a.Maintainer, Roles: a.Roles})
+	}
+	// Changing roles may take [RoleApprove] away from an approver
+	threshold, err := storage.GetApprovalThreshold(ctx, mu, a.Project)
+	if err != nil {
+		return false, AddMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if output := checkApprovers(maintainers, auth.Actor(), threshold); output != nil {
+		return false, AddMaintainerComputeUnits, output, nil, nil
+	}
+	if err := storage.SetMaintainers(ctx, mu, a.Project, maintainers); err != nil {
+		return false, AddMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, AddMaintainerComputeUnits, nil, nil, nil
+}
+
+func (*AddMaintainer) MaxComputeUnits(chain.Rules) uint64 {
+	return AddMaintainerComputeUnits
+}
+
+func (*AddMaintainer) Size() int {
+	return consts.IDLen + codec.AddressLen + consts.Uint8Len
+}
+
+func (a *AddMaintainer) Marshal(p *codec.Packer) {
+	p.PackID(a.Project)
+	p.PackAddress(a.Maintainer)
+	p.PackByte(a.Roles)
+}
+
+func UnmarshalAddMaintainer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var add AddMaintainer
+	p.UnpackID(true, &add.Project)
+	p.UnpackAddress(&add.Maintainer)
+	add.Roles = p.UnpackByte()
+	return &add, p.Err()
+}
+
+func (*AddMaintainer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
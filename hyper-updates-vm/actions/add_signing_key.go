@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*AddSigningKey)(nil)
+
+// AddSigningKey registers a vendor code-signing key. Once a project has a
+// signing key, every update must be signed by one of its keys.
+type AddSigningKey struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// PublicKey verifies signatures over [storage.UpdateSigningMessage].
+	PublicKey ed25519.PublicKey `json:"public_key"`
+}
+
+func (*AddSigningKey) GetTypeID() uint8 {
+	return addSigningKeyID
+}
+
+func (a *AddSigningKey) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(a.Project)),
+		string(storage.LegacyProjectKey(a.Project)),
+		string(storage.SigningKeysKey(a.Project)),
+	}
+}
+
+func (*AddSigningKey) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.SigningKeysChunks}
+}
+
+func (*AddSigningKey) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *AddSigningKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if a.PublicKey == ed25519.EmptyPublicKey {
+		return false, AddSigningKeyComputeUnits, OutputSigningKeyInvalid, nil, nil
+	}
+	// Only the owner may change who can vouch for a build
+	if output := authorizeProject(ctx, mu, a.Project, auth.Actor(), 0); output != nil {
+		return false, AddSigningKeyComputeUnits, output, nil, nil
+	}
+	keys, err := storage.GetSigningKeys(ctx, mu, a.Project)
+	if err != nil {
+		return false, AddSigningKeyComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	for _, key := range keys {
+		if key == a.PublicKey {
+			return false, AddSigningKeyComputeUnits, OutputSigningKeyExists, nil, nil
+		}
+	}
+	if len(keys) >= storage.MaxProjectSigningKeys {
+		return false, AddSigningKeyComputeUnits, OutputTooManySigningKeys, nil, nil
+	}
+	if err := storage.SetSigningKeys(ctx, mu, a.Project, append(keys, a.PublicKey)); err != nil {
+		return false, AddSigningKeyComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, AddSigningKeyComputeUnits, nil, nil, nil
+}
+
+func (*AddSigningKey) MaxComputeUnits(chain.Rules) uint64 {
+	return AddSigningKeyComputeUnits
+}
+
+func (*AddSigningKey) Size() int {
+	return consts.IDLen + ed25519.PublicKeyLen
+}
+
+func (a *AddSigningKey) Marshal(p *codec.Packer) {
+	p.PackID(a.Project)
+	p.PackFixedBytes(a.PublicKey[:])
+}
+
+func UnmarshalAddSigningKey(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var add AddSigningKey
+	p.UnpackID(true, &add.Project)
+	pk := add.PublicKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &pk)
+	return &add, p.Err()
+}
+
+func (*AddSigningKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ApproveUpdate)(nil)
+
+// ApproveUpdate signs off on a draft update. The approval that meets the
+// threshold of the update releases it to devices.
+type ApproveUpdate struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// ForDeviceName and Channel must match the update, they select the
+	// latest update pointer moved on release.
+	ForDeviceName []byte `json:"for_device_name"`
+	Channel       uint8  `json:"channel"`
+}
+
+func (*ApproveUpdate) GetTypeID() uint8 {
+	return approveUpdateID
+}
+
+func (a *ApproveUpdate) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(a.Project)),
+		string(storage.LegacyProjectKey(a.Project)),
+		string(storage.MaintainersKey(a.Project)),
+		string(storage.UpdateKey(a.Update)),
+		string(storage.LegacyUpdateKey(a.Update)),
+		string(storage.RevocationKey(a.Update)),
+		string(storage.UpdateResultsKey(a.Update)),
+		string(storage.RolloutKey(a.Update)),
+		string(storage.ApprovalsKey(a.Update)),
+		string(storage.LatestUpdateKey(a.Project, a.ForDeviceName, a.Channel)),
+	}
+}
+
+func (*ApproveUpdate) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.ApprovalsChunks,
+		storage.LatestUpdateChunks,
+	}
+}
+
+func (*ApproveUpdate) OutputsWarpMessage() bool {
+	return false
+}
+
+func (a *ApproveUpdate) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.Actor()
+	if output := authorizeProject(ctx, mu, a.Project, actor, RoleApprove); output != nil {
+		return false, ApproveUpdateComputeUnits, output, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, a.Update)
+	if err != nil {
+		return false, ApproveUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ApproveUpdateComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != a.Project {
+		return false, ApproveUpdateComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if !bytes.Equal(update.ForDeviceName, a.ForDeviceName) {
+		return false, ApproveUpdateComputeUnits, OutputUpdateDeviceMismatch, nil, nil
+	}
+	if update.Channel != a.Channel {
+		return false, ApproveUpdateComputeUnits, OutputUpdateChannelMismatch, nil, nil
+	}
+	if update.Revoked {
+		return false, ApproveUpdateComputeUnits, OutputUpdateRevoked, nil, nil
+	}
+
+	exists, approvals, err := storage.GetApprovals(ctx, mu, a.Update)
+	if err != nil {
+		return false, ApproveUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists || !approvals.Draft() {
+		return false, ApproveUpdateComputeUnits, OutputUpdateNotDraft, nil, nil
+	}
+	// A sign-off must come from someone other than the publisher
+	if approvals.Author == actor {
+		return false, ApproveUpdateComputeUnits, OutputApproverIsAuthor, nil, nil
+	}
+	if approvals.Approved(actor) {
+		return false, ApproveUpdateComputeUnits, OutputUpdateAlreadyApproved, nil, nil
+	}
+	approvals.Approvals = append(approvals.Approvals, storage.Approval{Approver: actor, Timestamp: timestamp})
+
+	if len(approvals.Approvals) >= int(approvals.Threshold) {
+		// Releases may have moved the channel past the draft while it was
+		// waiting, it must still move forward
+		exists, _, latestVersion, err := storage.GetLatestUpdate(ctx, mu, a.Project, a.ForDeviceName, a.Channel)
+		if err != nil {
+			return false, ApproveUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if exists && storage.CompareSemVer(update.UpdateVersion, latestVersion) <= 0 {
+			return false, ApproveUpdateComputeUnits, OutputUpdateVersionNotIncreasing, nil, nil
+		}
+		if err := storage.SetLatestUpdate(ctx, mu, a.Project, a.ForDeviceName, a.Channel, a.Update, update.UpdateVersion); err != nil {
+			return false, ApproveUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		approvals.ReleasedAt = timestamp
+	}
+	if err := storage.SetApprovals(ctx, mu, a.Update, approvals); err != nil {
+		return false, ApproveUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ApproveUpdateComputeUnits, nil, nil, nil
+}
+
+func (*ApproveUpdate) MaxComputeUnits(chain.Rules) uint64 {
+	return ApproveUpdateComputeUnits
+}
+
+func (a *ApproveUpdate) Size() int {
+	return consts.IDLen*2 + codec.BytesLen(a.ForDeviceName) + consts.Uint8Len
+}
+
+func (a *ApproveUpdate) Marshal(p *codec.Packer) {
+	p.PackID(a.Project)
+	p.PackID(a.Update)
+	p.PackBytes(a.ForDeviceName)
+	p.PackByte(a.Channel)
+}
+
+func UnmarshalApproveUpdate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var approve ApproveUpdate
+	p.UnpackID(true, &approve.Project)
+	p.UnpackID(true, &approve.Update)
+	p.UnpackBytes(ForDeviceNameUnits, true, &approve.ForDeviceName)
+	approve.Channel = p.UnpackByte()
+	return &approve, p.Err()
+}
+
+func (*ApproveUpdate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
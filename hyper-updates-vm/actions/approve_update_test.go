@@ -0,0 +1,195 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// testAuth signs transactions as [actor].
+type testAuth struct {
+	chain.Auth
+	actor codec.Address
+}
+
+func (a testAuth) Actor() codec.Address { return a.actor }
+
+func (a testAuth) Sponsor() codec.Address { return a.actor }
+
+func TestCheckApprovers(t *testing.T) {
+	owner := testAddress()
+	approver := testAddress()
+	other := testAddress()
+	maintainers := []storage.Maintainer{
+		{Address: approver, Roles: RoleApprove},
+		{Address: other, Roles: RoleRelease | RoleApprove},
+		{Address: testAddress(), Roles: RoleRelease},
+	}
+
+	tests := []struct {
+		name        string
+		maintainers []storage.Maintainer
+		owner       codec.Address
+		threshold   uint8
+		output      []byte
+	}{
+		{name: "no threshold", owner: owner},
+		{name: "no threshold and approvers", maintainers: maintainers, owner: owner},
+		{name: "every approver", maintainers: maintainers, owner: owner, threshold: 2},
+		{name: "too few approvers", maintainers: maintainers, owner: owner, threshold: 3, output: OutputApprovalThresholdUnreachable},
+		{name: "no approvers", owner: owner, threshold: 1, output: OutputApprovalThresholdUnreachable},
+		// An approver that becomes the owner no longer counts
+		{name: "approver owns", maintainers: maintainers, owner: approver, threshold: 2, output: OutputApprovalThresholdUnreachable},
+		{name: "approver owns below threshold", maintainers: maintainers, owner: approver, threshold: 1},
+	}
+	for _, tt := range tests {
+		output := checkApprovers(tt.maintainers, tt.owner, tt.threshold)
+		if !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: output = %q, want %q", tt.name, output, tt.output)
+		}
+	}
+}
+
+func TestApproveUpdate(t *testing.T) {
+	owner := testAddress()
+	first := testAddress()
+	second := testAddress()
+	releaser := testAddress()
+	device := []byte("thermostat")
+	version := storage.SemVer{Major: 1, Minor: 1}
+
+	tests := []struct {
+		name     string
+		approver codec.Address
+		channel  uint8
+		project  bool // approve under another project
+		revoked  bool
+		latest   storage.SemVer
+		approved []codec.Address
+		released int64
+		draft    bool
+		output   []byte
+		release  bool
+	}{
+		{name: "first approval", approver: first, draft: true},
+		{name: "threshold approval", approver: second, approved: []codec.Address{first}, draft: true, release: true},
+		{name: "owner approval", approver: owner, approved: []codec.Address{first}, draft: true, release: true},
+		{name: "author", approver: releaser, draft: true, output: OutputApproverIsAuthor},
+		{name: "stranger", approver: testAddress(), draft: true, output: OutputNotProjectMaintainer},
+		{name: "approved twice", approver: first, approved: []codec.Address{first}, draft: true, output: OutputUpdateAlreadyApproved},
+		{name: "released", approver: second, approved: []codec.Address{first}, released: 1, draft: true, output: OutputUpdateNotDraft},
+		{name: "not a draft", approver: first, output: OutputUpdateNotDraft},
+		{name: "revoked", approver: first, revoked: true, draft: true, output: OutputUpdateRevoked},
+		{name: "wrong channel", approver: first, channel: 1, draft: true, output: OutputUpdateChannelMismatch},
+		{name: "wrong project", approver: first, project: true, draft: true, output: OutputUpdateProjectMismatch},
+		{
+			name:     "passed by a release",
+			approver: second,
+			approved: []codec.Address{first},
+			latest:   storage.SemVer{Major: 2},
+			draft:    true,
+			output:   OutputUpdateVersionNotIncreasing,
+		},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		mu := testState{}
+		project := setTestProject(
+			t,
+			mu,
+			owner,
+			storage.Maintainer{Address: first, Roles: RoleApprove},
+			storage.Maintainer{Address: second, Roles: RoleApprove},
+			storage.Maintainer{Address: releaser, Roles: RoleRelease | RoleApprove},
+		)
+		update := ids.GenerateTestID()
+		if err := storage.SetUpdate(
+			ctx,
+			mu,
+			update,
+			[]byte(project.String()),
+			[]byte("sha256:00"),
+			[]byte("https://ipfs.io/ipfs/cid"),
+			device,
+			version,
+			0,
+			nil,
+			storage.UpdatePatch{},
+			storage.UpdateWindow{},
+		); err != nil {
+			t.Fatal(err)
+		}
+		if tt.draft {
+			approvals := storage.UpdateApprovals{Threshold: 2, Author: releaser, ReleasedAt: tt.released}
+			for _, approver := range tt.approved {
+				approvals.Approvals = append(approvals.Approvals, storage.Approval{Approver: approver, Timestamp: 1})
+			}
+			if err := storage.SetApprovals(ctx, mu, update, approvals); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tt.revoked {
+			if err := storage.SetRevocation(ctx, mu, update, RevokeReasonDefective, 1, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		latest := ids.GenerateTestID()
+		if tt.latest != (storage.SemVer{}) {
+			if err := storage.SetLatestUpdate(ctx, mu, project, device, 0, latest, tt.latest); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if tt.project {
+			project = setTestProject(t, mu, owner, storage.Maintainer{Address: first, Roles: RoleApprove})
+		}
+
+		approve := &ApproveUpdate{Project: project, Update: update, ForDeviceName: device, Channel: tt.channel}
+		success, _, output, _, err := approve.Execute(ctx, nil, mu, 2, testAuth{actor: tt.approver}, ids.Empty, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if success != (tt.output == nil) || !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: Execute = %t, %q, want %q", tt.name, success, output, tt.output)
+			continue
+		}
+		if !success {
+			continue
+		}
+
+		_, approvals, err := storage.GetApprovals(ctx, mu, update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !approvals.Approved(tt.approver) {
+			t.Errorf("%s: approval not recorded", tt.name)
+		}
+		exists, got, _, err := storage.GetLatestUpdate(ctx, mu, project, device, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.release {
+			if approvals.Draft() || approvals.ReleasedAt != 2 {
+				t.Errorf("%s: released at %d, want 2", tt.name, approvals.ReleasedAt)
+			}
+			if !exists || got != update {
+				t.Errorf("%s: latest update = %s, want %s", tt.name, got, update)
+			}
+		} else {
+			if !approvals.Draft() {
+				t.Errorf("%s: released below the threshold", tt.name)
+			}
+			if exists {
+				t.Errorf("%s: latest update moved to %s", tt.name, got)
+			}
+		}
+	}
+}
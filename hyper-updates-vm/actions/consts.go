@@ -16,6 +16,40 @@ const (
 	transferID      uint8 = 8
 	createProjectID uint8 = 9
 	createUpdateID  uint8 = 10
+
+	addMaintainerID    uint8 = 11
+	removeMaintainerID uint8 = 12
+	revokeUpdateID     uint8 = 13
+
+	reportUpdateResultID uint8 = 14
+
+	registerDeviceID     uint8 = 15
+	updateDeviceStatusID uint8 = 16
+	decommissionDeviceID uint8 = 17
+
+	promoteUpdateID        uint8 = 18
+	setRolloutPercentageID uint8 = 19
+
+	proposeProjectOwnerID uint8 = 20
+	acceptProjectOwnerID  uint8 = 21
+
+	editProjectID uint8 = 22
+
+	addSigningKeyID    uint8 = 23
+	removeSigningKeyID uint8 = 24
+
+	setApprovalThresholdID uint8 = 25
+	approveUpdateID        uint8 = 26
+
+	setLicenseAssetID uint8 = 27
+
+	exportUpdateID uint8 = 28
+	importUpdateID uint8 = 29
+
+	addImportSourceID    uint8 = 30
+	removeImportSourceID uint8 = 31
+
+	deviceHeartbeatID uint8 = 32
 )
 
 const (
@@ -39,11 +73,12 @@ const (
 
 // Project storage constants
 const (
-	ProjectNameUnits          = 32
-	ProjectLogoUnits          = 100
-	ProjectDescriptionUnits   = 100
+	ProjectNameUnits          = 64   // storage.MaxProjectNameLen
+	ProjectLogoUnits          = 256  // storage.MaxProjectLogoLen
+	ProjectDescriptionUnits   = 1024 // storage.MaxProjectDescriptionLen
 	ProjectOwnerUnits         = 500
 	CreateProjectComputeUnits = 5
+	EditProjectComputeUnits   = 5
 )
 
 // Update storage constants
@@ -52,7 +87,117 @@ const (
 	UpdateExecutableHashUnits = 100
 	UpdateExecutableIPFSUrl   = 100
 	ForDeviceNameUnits        = 100
-	UpdateVersionUnits        = 1
-	SuccessCountUnits         = 1
+	UpdateVersionUnits        = 256 // MAJOR.MINOR.PATCH-PRERELEASE+BUILD
 	CreateUpdateComputeUnits  = 5
 )
+
+// Channel constants
+const (
+	// Release channels, devices follow exactly one of them. Stable is the zero
+	// value so updates published before channels existed stay on it.
+	ChannelStable uint8 = iota
+	ChannelBeta
+	ChannelCanary
+
+	MaxChannel = ChannelCanary
+
+	PromoteUpdateComputeUnits = 5
+)
+
+// Rollout constants
+const (
+	SetRolloutPercentageComputeUnits = 2
+)
+
+// Maintainer constants
+const (
+	// Roles a project owner can grant to a maintainer. The owner implicitly
+	// holds all of them.
+	RoleRelease      uint8 = 1 << iota // publish updates
+	RoleYank                           // revoke published updates
+	RoleEditMetadata                   // change project metadata
+	RoleReport                         // report install results for devices
+	RoleApprove                        // sign off on draft updates
+
+	AllRoles = RoleRelease | RoleYank | RoleEditMetadata | RoleReport | RoleApprove
+
+	AddMaintainerComputeUnits    = 5
+	RemoveMaintainerComputeUnits = 5
+
+	ProposeProjectOwnerComputeUnits = 2
+	AcceptProjectOwnerComputeUnits  = 5
+)
+
+// Signing key constants
+const (
+	UpdateSignatureUnits = 64 // ed25519 signature
+
+	AddSigningKeyComputeUnits    = 5
+	RemoveSigningKeyComputeUnits = 5
+)
+
+// Approval constants
+const (
+	SetApprovalThresholdComputeUnits = 2
+	ApproveUpdateComputeUnits        = 5
+)
+
+// License constants
+const (
+	SetLicenseAssetComputeUnits = 2
+)
+
+// Replication constants
+const (
+	ExportUpdateComputeUnits = 10
+	ImportUpdateComputeUnits = 10
+
+	AddImportSourceComputeUnits    = 5
+	RemoveImportSourceComputeUnits = 5
+)
+
+// Revocation constants
+const (
+	// Reasons an update can be revoked for
+	RevokeReasonDefective     uint8 = iota + 1 // binary misbehaves on devices
+	RevokeReasonSecurity                       // binary has a known vulnerability
+	RevokeReasonWrongArtifact                  // hash or url point at the wrong build
+	RevokeReasonSuperseded                     // replaced by a newer release
+	RevokeReasonOther                          // see the revocation note
+
+	MaxRevokeReason = RevokeReasonOther
+
+	RevokeNoteUnits          = 256
+	RevokeUpdateComputeUnits = 5
+)
+
+// Install result constants
+const (
+	// Outcomes a device can report after an OTA attempt
+	ResultSuccess    uint8 = iota + 1
+	ResultFailure          // install failed, device kept the old binary
+	ResultRolledBack       // install completed but the device reverted
+
+	MaxResultStatus = ResultRolledBack
+
+	ReportUpdateResultComputeUnits = 2
+)
+
+// Device constants
+const (
+	// Lifecycle of a registered device
+	DeviceStatusActive         uint8 = iota + 1
+	DeviceStatusOffline              // device stopped checking in
+	DeviceStatusFaulty               // device reports a hardware or software fault
+	DeviceStatusDecommissioned       // device was retired, see [DecommissionDevice]
+
+	// Decommissioning is permanent and has its own action
+	MaxReportableDeviceStatus = DeviceStatusFaulty
+
+	DeviceModelUnits = 64
+
+	RegisterDeviceComputeUnits     = 5
+	UpdateDeviceStatusComputeUnits = 2
+	DecommissionDeviceComputeUnits = 2
+	DeviceHeartbeatComputeUnits    = 1
+)
@@ -6,7 +6,6 @@ package actions
 import (
 	"context"
 
-	"hyper-updates/consts"
 	"hyper-updates/storage"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -36,7 +35,7 @@ func (*CreateProject) StateKeys(_ chain.Auth, txID ids.ID) []string {
 }
 
 func (*CreateProject) StateKeysMaxChunks() []uint16 {
-	return []uint16{storage.ProjectDescriptionChunks}
+	return []uint16{storage.ProjectChunks}
 }
 
 func (*CreateProject) OutputsWarpMessage() bool {
@@ -59,15 +58,9 @@ func (c *CreateProject) Execute(
 		return false, CreateAssetComputeUnits, OutputProjectDescriptionNotGiven, nil, nil
 	}
 
-	owner, err := codec.AddressBech32(consts.HRP, auth.Actor())
-
-	if err != nil {
-		return false, CreateAssetComputeUnits, OutputProjectInvalidOwner, nil, nil
-	}
-
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetProject(ctx, mu, txID, c.ProjectName, c.ProjectDescription, []byte(owner), c.Logo); err != nil {
+	if err := storage.SetProject(ctx, mu, txID, c.ProjectName, c.ProjectDescription, auth.Actor(), c.Logo); err != nil {
 		return false, CreateProjectComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	return true, CreateProjectComputeUnits, nil, nil, nil
@@ -107,3 +100,10 @@ func (*CreateProject) ValidRange(chain.Rules) (int64, int64) {
 	// Returning -1, -1 means that the action is always valid.
 	return -1, -1
 }
+
+// ParseProjectID decodes the project reference carried by project-scoped
+// actions. Projects are referenced by the string form of the [CreateProject]
+// transaction ID.
+func ParseProjectID(b []byte) (ids.ID, error) {
+	return ids.FromString(string(b))
+}
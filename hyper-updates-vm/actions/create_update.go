@@ -4,7 +4,9 @@
 package actions
 
 import (
+	"bytes"
 	"context"
+	"errors"
 
 	"hyper-updates/storage"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/state"
 	"github.com/ava-labs/hypersdk/utils"
 )
@@ -19,26 +22,122 @@ import (
 var _ chain.Action = (*CreateUpdate)(nil)
 
 type CreateUpdate struct {
-	ProjectTxID          []byte `json:"project_id"` // reference to Project
-	UpdateExecutableHash []byte `json:"executable_hash"`
+	ProjectTxID          []byte `json:"project_id"`      // reference to Project
+	UpdateExecutableHash []byte `json:"executable_hash"` // encoded [storage.Digest]
 	UpdateIPFSUrl        []byte `json:"executable_ipfs_url"`
 	ForDeviceName        []byte `json:"for_device_name"`
-	UpdateVersion        uint8  `json:"version"`
-	SuccessCount         uint8  `json:"success_count"`
+	UpdateVersion        []byte `json:"version"` // semantic version, e.g. 2.10.1-rc.3
+	Channel              uint8  `json:"channel"` // one of the Channel* codes
+
+	// RolloutPercentage is the share of the fleet the update is initially
+	// offered to, it can be raised later with [SetRolloutPercentage].
+	RolloutPercentage uint8 `json:"rollout_percentage"`
+
+	// Signature is a vendor signature over [storage.UpdateSigningMessage]. It
+	// is required once the project has registered a signing key.
+	Signature []byte `json:"signature"`
+
+	// BaseUpdate optionally ships the update as a binary patch against the
+	// image of an earlier update of the same device. The patch is published
+	// next to the full image, [PatchHash] is its encoded [storage.Digest].
+	BaseUpdate   ids.ID `json:"base_update"`
+	PatchHash    []byte `json:"patch_hash"`
+	PatchIPFSUrl []byte `json:"patch_ipfs_url"`
+
+	// Manifest optionally ships the release as several artifacts installed
+	// in order. Its app artifact must be the executable above, so devices
+	// that only install apps keep working.
+	Manifest storage.Manifest `json:"manifest"`
+
+	// Constraints optionally restrict the hardware and versions the update
+	// can be installed on.
+	Constraints storage.UpdateConstraints `json:"constraints"`
+
+	// NotBefore and ExpiresAt optionally bound when the update can be
+	// installed, in milliseconds. Zero leaves that side open.
+	NotBefore int64 `json:"not_before"`
+	ExpiresAt int64 `json:"expires_at"`
+
+	// License optionally restricts the update to devices holding the
+	// license asset of the project.
+	License storage.UpdateLicense `json:"license"`
 }
 
 func (*CreateUpdate) GetTypeID() uint8 {
 	return createUpdateID
 }
 
-func (*CreateUpdate) StateKeys(_ chain.Auth, txID ids.ID) []string {
-	return []string{
+func (c *CreateUpdate) StateKeys(_ chain.Auth, txID ids.ID) []string {
+	keys := []string{
 		string(storage.UpdateKey(txID)),
+		string(storage.RolloutKey(txID)),
+		string(storage.ManifestKey(txID)),
+		string(storage.ConstraintsKey(txID)),
+		string(storage.ApprovalsKey(txID)),
+		string(storage.UpdateLicenseKey(txID)),
+	}
+	// An unparsable reference is rejected in [Execute], so there is no project
+	// record to read.
+	if project, err := ParseProjectID(c.ProjectTxID); err == nil {
+		keys = append(keys,
+			string(storage.ProjectKey(project)),
+			string(storage.LegacyProjectKey(project)),
+			string(storage.MaintainersKey(project)),
+			string(storage.LatestUpdateKey(project, c.ForDeviceName, c.Channel)),
+			string(storage.SigningKeysKey(project)),
+			string(storage.ApprovalThresholdKey(project)),
+			string(storage.LicenseAssetKey(project)),
+		)
 	}
+	if c.BaseUpdate != ids.Empty {
+		keys = append(keys,
+			string(storage.UpdateKey(c.BaseUpdate)),
+			string(storage.LegacyUpdateKey(c.BaseUpdate)),
+			string(storage.RevocationKey(c.BaseUpdate)),
+			string(storage.UpdateResultsKey(c.BaseUpdate)),
+			string(storage.RolloutKey(c.BaseUpdate)),
+		)
+	}
+	if pass := c.Constraints.PassThrough; pass != ids.Empty {
+		keys = append(keys,
+			string(storage.UpdateKey(pass)),
+			string(storage.LegacyUpdateKey(pass)),
+			string(storage.RevocationKey(pass)),
+			string(storage.UpdateResultsKey(pass)),
+			string(storage.RolloutKey(pass)),
+			string(storage.ApprovalsKey(pass)),
+		)
+	}
+	return keys
 }
 
 func (*CreateUpdate) StateKeysMaxChunks() []uint16 {
-	return []uint16{storage.UpdateExecutableHashChunks}
+	return []uint16{
+		storage.UpdateChunks,
+		storage.RolloutChunks,
+		storage.ManifestChunks,
+		storage.ConstraintsChunks,
+		storage.ApprovalsChunks,
+		storage.UpdateLicenseChunks,
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.LatestUpdateChunks,
+		storage.SigningKeysChunks,
+		storage.ThresholdChunks,
+		storage.LicenseAssetChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.ApprovalsChunks,
+	}
 }
 
 func (*CreateUpdate) OutputsWarpMessage() bool {
@@ -49,7 +148,7 @@ func (c *CreateUpdate) Execute(
 	ctx context.Context,
 	_ chain.Rules,
 	mu state.Mutable,
-	_ int64,
+	timestamp int64,
 	auth chain.Auth,
 	txID ids.ID,
 	_ bool,
@@ -58,9 +157,17 @@ func (c *CreateUpdate) Execute(
 	if len(c.ProjectTxID) == 0 {
 		return false, CreateUpdateComputeUnits, OutputProjectTxIdNotProvided, nil, nil
 	}
+	projectID, err := ParseProjectID(c.ProjectTxID)
+	if err != nil {
+		return false, CreateUpdateComputeUnits, OutputProjectTxIdInvalid, nil, nil
+	}
 	if len(c.UpdateExecutableHash) == 0 {
 		return false, CreateUpdateComputeUnits, OutputUpdateExecutableHashNotProvided, nil, nil
 	}
+	// New releases can't be published with a weak digest
+	if digest, err := storage.ParseDigest(c.UpdateExecutableHash); err != nil || digest.Weak() {
+		return false, CreateUpdateComputeUnits, OutputUpdateDigestInvalid, nil, nil
+	}
 
 	if len(c.ForDeviceName) == 0 {
 		return false, CreateAssetComputeUnits, OutputForDeviceNameNotProvided, nil, nil
@@ -70,13 +177,199 @@ func (c *CreateUpdate) Execute(
 		return false, CreateAssetComputeUnits, OutputUpdateExecutableIPFSNotProvided, nil, nil
 	}
 
-	if c.UpdateVersion == 0 {
+	if len(c.UpdateVersion) == 0 {
 		return false, CreateAssetComputeUnits, OutputUpdateVersionNotProvided, nil, nil
 	}
+	version, err := storage.ParseSemVer(string(c.UpdateVersion))
+	if err != nil {
+		return false, CreateUpdateComputeUnits, OutputUpdateVersionInvalid, nil, nil
+	}
+
+	if c.Channel > MaxChannel {
+		return false, CreateUpdateComputeUnits, OutputChannelInvalid, nil, nil
+	}
+
+	if c.RolloutPercentage > storage.MaxRolloutPercentage {
+		return false, CreateUpdateComputeUnits, OutputRolloutPercentageInvalid, nil, nil
+	}
+
+	if c.BaseUpdate == ids.Empty && (len(c.PatchHash) > 0 || len(c.PatchIPFSUrl) > 0) {
+		return false, CreateUpdateComputeUnits, OutputPatchBaseNotProvided, nil, nil
+	}
+	if c.BaseUpdate != ids.Empty {
+		if digest, err := storage.ParseDigest(c.PatchHash); err != nil || digest.Weak() {
+			return false, CreateUpdateComputeUnits, OutputPatchDigestInvalid, nil, nil
+		}
+		if len(c.PatchIPFSUrl) == 0 {
+			return false, CreateUpdateComputeUnits, OutputPatchIPFSNotProvided, nil, nil
+		}
+	}
+
+	// The signature of a multi-artifact release covers every artifact
+	signedDigest := c.UpdateExecutableHash
+	if len(c.Manifest) > 0 {
+		if err := c.Manifest.Validate(); err != nil {
+			return false, CreateUpdateComputeUnits, OutputManifestInvalid, nil, nil
+		}
+		app, ok := c.Manifest.App()
+		if !ok || !bytes.Equal(app.Digest, c.UpdateExecutableHash) || !bytes.Equal(app.IPFSUrl, c.UpdateIPFSUrl) {
+			return false, CreateUpdateComputeUnits, OutputManifestAppMismatch, nil, nil
+		}
+		signedDigest = c.Manifest.Digest().Bytes()
+	}
+
+	if err := c.Constraints.Validate(); err != nil {
+		return false, CreateUpdateComputeUnits, OutputConstraintsInvalid, nil, nil
+	}
+
+	window := storage.UpdateWindow{NotBefore: c.NotBefore, ExpiresAt: c.ExpiresAt}
+	if err := window.Validate(); err != nil {
+		return false, CreateUpdateComputeUnits, OutputUpdateWindowInvalid, nil, nil
+	}
+	if errors.Is(window.Check(timestamp), storage.ErrUpdateExpired) {
+		return false, CreateUpdateComputeUnits, OutputUpdateWindowExpired, nil, nil
+	}
+
+	if output := authorizeProject(ctx, mu, projectID, auth.Actor(), RoleRelease); output != nil {
+		return false, CreateUpdateComputeUnits, output, nil, nil
+	}
+
+	// Premium updates are licensed in the asset the project sells licenses
+	// in
+	if !c.License.Empty() {
+		if c.License.MinBalance == 0 {
+			return false, CreateUpdateComputeUnits, OutputLicenseInvalid, nil, nil
+		}
+		asset, err := storage.GetLicenseAsset(ctx, mu, projectID)
+		if err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if asset != c.License.Asset {
+			return false, CreateUpdateComputeUnits, OutputLicenseNotProjectAsset, nil, nil
+		}
+	}
+
+	// Once a project registers signing keys, only builds vouched for by the
+	// vendor can be published
+	keys, err := storage.GetSigningKeys(ctx, mu, projectID)
+	if err != nil {
+		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if len(keys) > 0 && len(c.Signature) == 0 {
+		return false, CreateUpdateComputeUnits, OutputUpdateSignatureMissing, nil, nil
+	}
+	if len(c.Signature) > 0 {
+		msg := storage.UpdateSigningMessage(projectID, version, c.ForDeviceName, signedDigest)
+		if _, ok := storage.VerifyUpdateSignature(keys, msg, c.Signature); !ok {
+			return false, CreateUpdateComputeUnits, OutputUpdateSignatureInvalid, nil, nil
+		}
+	}
+
+	// A patch can only be applied on top of an older image of the same
+	// device, revoked images may still be on devices so they remain valid
+	// bases
+	if c.BaseUpdate != ids.Empty {
+		exists, base, err := storage.GetUpdate(ctx, mu, c.BaseUpdate)
+		if err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if !exists {
+			return false, CreateUpdateComputeUnits, OutputPatchBaseNotFound, nil, nil
+		}
+		baseProject, err := ParseProjectID(base.ProjectTxID)
+		if err != nil || baseProject != projectID || !bytes.Equal(base.ForDeviceName, c.ForDeviceName) {
+			return false, CreateUpdateComputeUnits, OutputPatchBaseMismatch, nil, nil
+		}
+		if storage.CompareSemVer(version, base.UpdateVersion) <= 0 {
+			return false, CreateUpdateComputeUnits, OutputPatchBaseNotOlder, nil, nil
+		}
+	}
+
+	// Devices are routed through the pass-through release first, it must be
+	// an older release of the same device that can still be installed
+	if pass := c.Constraints.PassThrough; pass != ids.Empty {
+		exists, passUpdate, err := storage.GetUpdate(ctx, mu, pass)
+		if err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if !exists {
+			return false, CreateUpdateComputeUnits, OutputPassThroughNotFound, nil, nil
+		}
+		passProject, err := ParseProjectID(passUpdate.ProjectTxID)
+		if err != nil || passProject != projectID || !bytes.Equal(passUpdate.ForDeviceName, c.ForDeviceName) {
+			return false, CreateUpdateComputeUnits, OutputPassThroughMismatch, nil, nil
+		}
+		if storage.CompareSemVer(version, passUpdate.UpdateVersion) <= 0 {
+			return false, CreateUpdateComputeUnits, OutputPassThroughNotOlder, nil, nil
+		}
+		if passUpdate.Revoked {
+			return false, CreateUpdateComputeUnits, OutputPassThroughRevoked, nil, nil
+		}
+		if errors.Is(passUpdate.Check(timestamp), storage.ErrUpdateExpired) {
+			return false, CreateUpdateComputeUnits, OutputPassThroughExpired, nil, nil
+		}
+		exists, passApprovals, err := storage.GetApprovals(ctx, mu, pass)
+		if err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if exists && passApprovals.Draft() {
+			return false, CreateUpdateComputeUnits, OutputPassThroughDraft, nil, nil
+		}
+	}
+
+	// Releases for a device must always move forward on a channel
+	exists, _, latestVersion, err := storage.GetLatestUpdate(ctx, mu, projectID, c.ForDeviceName, c.Channel)
+	if err != nil {
+		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && storage.CompareSemVer(version, latestVersion) <= 0 {
+		return false, CreateUpdateComputeUnits, OutputUpdateVersionNotIncreasing, nil, nil
+	}
 
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetUpdate(ctx, mu, txID, c.ProjectTxID, c.UpdateExecutableHash, c.UpdateIPFSUrl, c.ForDeviceName, byte(c.UpdateVersion), byte(c.SuccessCount)); err != nil {
+	if err := storage.SetUpdate(ctx, mu, txID, c.ProjectTxID, c.UpdateExecutableHash, c.UpdateIPFSUrl, c.ForDeviceName, version, c.Channel, c.Signature, storage.UpdatePatch{
+		BaseUpdate:   c.BaseUpdate,
+		PatchHash:    c.PatchHash,
+		PatchIPFSUrl: c.PatchIPFSUrl,
+	}, window); err != nil {
+		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetRollout(ctx, mu, txID, c.RolloutPercentage); err != nil {
+		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if len(c.Manifest) > 0 {
+		if err := storage.SetManifest(ctx, mu, txID, c.Manifest); err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if !c.Constraints.Empty() {
+		if err := storage.SetConstraints(ctx, mu, txID, c.Constraints); err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if !c.License.Empty() {
+		if err := storage.SetUpdateLicense(ctx, mu, txID, c.License); err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+
+	// Projects requiring sign-offs publish drafts, the latest update pointer
+	// only moves once [ApproveUpdate] releases them
+	threshold, err := storage.GetApprovalThreshold(ctx, mu, projectID)
+	if err != nil {
+		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if threshold > 0 {
+		if err := storage.SetApprovals(ctx, mu, txID, storage.UpdateApprovals{
+			Threshold: threshold,
+			Author:    auth.Actor(),
+		}); err != nil {
+			return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, CreateUpdateComputeUnits, nil, nil, nil
+	}
+	if err := storage.SetLatestUpdate(ctx, mu, projectID, c.ForDeviceName, c.Channel, txID, version); err != nil {
 		return false, CreateUpdateComputeUnits, utils.ErrBytes(err), nil, nil
 	}
 	return true, CreateUpdateComputeUnits, nil, nil, nil
@@ -92,8 +385,16 @@ func (c *CreateUpdate) Size() int {
 		codec.BytesLen(c.UpdateExecutableHash) +
 		codec.BytesLen(c.UpdateIPFSUrl) +
 		codec.BytesLen(c.ForDeviceName) +
-		UpdateVersionUnits +
-		SuccessCountUnits)
+		codec.BytesLen(c.UpdateVersion) +
+		consts.Uint8Len*2 +
+		codec.BytesLen(c.Signature) +
+		consts.IDLen +
+		codec.BytesLen(c.PatchHash) +
+		codec.BytesLen(c.PatchIPFSUrl) +
+		c.Manifest.Size() +
+		c.Constraints.Size() +
+		consts.Int64Len*2 +
+		consts.IDLen + consts.Uint64Len)
 
 }
 
@@ -102,8 +403,19 @@ func (c *CreateUpdate) Marshal(p *codec.Packer) {
 	p.PackBytes(c.UpdateExecutableHash)
 	p.PackBytes(c.UpdateIPFSUrl)
 	p.PackBytes(c.ForDeviceName)
-	p.PackByte(c.UpdateVersion)
-	p.PackByte(c.SuccessCount)
+	p.PackBytes(c.UpdateVersion)
+	p.PackByte(c.Channel)
+	p.PackByte(c.RolloutPercentage)
+	p.PackBytes(c.Signature)
+	p.PackID(c.BaseUpdate)
+	p.PackBytes(c.PatchHash)
+	p.PackBytes(c.PatchIPFSUrl)
+	storage.PackManifest(p, c.Manifest)
+	storage.PackConstraints(p, c.Constraints)
+	p.PackInt64(c.NotBefore)
+	p.PackInt64(c.ExpiresAt)
+	p.PackID(c.License.Asset)
+	p.PackUint64(c.License.MinBalance)
 
 }
 
@@ -115,9 +427,27 @@ func UnmarshalCreateUpdate(p *codec.Packer, _ *warp.Message) (chain.Action, erro
 	p.UnpackBytes(UpdateExecutableHashUnits, true, &create.UpdateExecutableHash)
 	p.UnpackBytes(UpdateExecutableIPFSUrl, true, &create.UpdateIPFSUrl)
 	p.UnpackBytes(ForDeviceNameUnits, true, &create.ForDeviceName)
-
-	create.UpdateVersion = uint8(p.UnpackByte())
-	create.SuccessCount = uint8(p.UnpackByte())
+	p.UnpackBytes(UpdateVersionUnits, true, &create.UpdateVersion)
+	create.Channel = p.UnpackByte()
+	create.RolloutPercentage = p.UnpackByte()
+	p.UnpackBytes(UpdateSignatureUnits, false, &create.Signature)
+	p.UnpackID(false, &create.BaseUpdate)
+	p.UnpackBytes(UpdateExecutableHashUnits, false, &create.PatchHash)
+	p.UnpackBytes(UpdateExecutableIPFSUrl, false, &create.PatchIPFSUrl)
+	manifest, err := storage.UnpackManifest(p)
+	if err != nil {
+		return nil, err
+	}
+	create.Manifest = manifest
+	constraints, err := storage.UnpackConstraints(p)
+	if err != nil {
+		return nil, err
+	}
+	create.Constraints = constraints
+	create.NotBefore = p.UnpackInt64(false)
+	create.ExpiresAt = p.UnpackInt64(false)
+	p.UnpackID(false, &create.License.Asset)
+	create.License.MinBalance = p.UnpackUint64(false)
 
 	return &create, p.Err()
 
@@ -0,0 +1,99 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*DecommissionDevice)(nil)
+
+type DecommissionDevice struct {
+	// Project is the [TxID] that created the project the device runs.
+	Project ids.ID `json:"project_id"`
+
+	// Device is the [storage.DeviceID] of the device.
+	Device ids.ID `json:"device_id"`
+}
+
+func (*DecommissionDevice) GetTypeID() uint8 {
+	return decommissionDeviceID
+}
+
+func (d *DecommissionDevice) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(d.Project)),
+		string(storage.LegacyProjectKey(d.Project)),
+		string(storage.MaintainersKey(d.Project)),
+		string(storage.DeviceKey(d.Device)),
+	}
+}
+
+func (*DecommissionDevice) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.DeviceChunks}
+}
+
+func (*DecommissionDevice) OutputsWarpMessage() bool {
+	return false
+}
+
+func (d *DecommissionDevice) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if output := authorizeProject(ctx, mu, d.Project, auth.Actor(), RoleEditMetadata); output != nil {
+		return false, DecommissionDeviceComputeUnits, output, nil, nil
+	}
+	data, output := getProjectDevice(ctx, mu, d.Project, d.Device)
+	if output != nil {
+		return false, DecommissionDeviceComputeUnits, output, nil, nil
+	}
+	// The record is kept so the inventory stays auditable
+	data.Status = DeviceStatusDecommissioned
+	data.UpdatedAt = timestamp
+	if err := storage.SetDevice(ctx, mu, data); err != nil {
+		return false, DecommissionDeviceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, DecommissionDeviceComputeUnits, nil, nil, nil
+}
+
+func (*DecommissionDevice) MaxComputeUnits(chain.Rules) uint64 {
+	return DecommissionDeviceComputeUnits
+}
+
+func (*DecommissionDevice) Size() int {
+	return consts.IDLen * 2
+}
+
+func (d *DecommissionDevice) Marshal(p *codec.Packer) {
+	p.PackID(d.Project)
+	p.PackID(d.Device)
+}
+
+func UnmarshalDecommissionDevice(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var decommission DecommissionDevice
+	p.UnpackID(true, &decommission.Project)
+	p.UnpackID(true, &decommission.Device)
+	return &decommission, p.Err()
+}
+
+func (*DecommissionDevice) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
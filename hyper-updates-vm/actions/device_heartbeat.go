@@ -0,0 +1,145 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/auth"
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*DeviceHeartbeat)(nil)
+
+// DeviceHeartbeat is sent periodically by a device, signed with its own key,
+// to report what it is running. It does not modify state, nodes index
+// accepted heartbeats in memory to answer fleet queries.
+type DeviceHeartbeat struct {
+	// Project is the [TxID] that created the project the device runs.
+	Project ids.ID `json:"project_id"`
+
+	// Device is the [storage.DeviceID] of the device.
+	Device ids.ID `json:"device_id"`
+
+	// Update is the [TxID] of the update the device runs, empty if it runs
+	// firmware that was not released through the project.
+	Update ids.ID `json:"update_id"`
+
+	// Version is the semantic version the device runs.
+	Version []byte `json:"version"`
+
+	// Uptime is the number of seconds since the device booted.
+	Uptime uint64 `json:"uptime"`
+}
+
+func (*DeviceHeartbeat) GetTypeID() uint8 {
+	return deviceHeartbeatID
+}
+
+func (h *DeviceHeartbeat) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.DeviceKey(h.Device)),
+		string(storage.UpdateKey(h.Update)),
+		string(storage.LegacyUpdateKey(h.Update)),
+		string(storage.RevocationKey(h.Update)),
+		string(storage.UpdateResultsKey(h.Update)),
+		string(storage.RolloutKey(h.Update)),
+	}
+}
+
+func (*DeviceHeartbeat) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.DeviceChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+	}
+}
+
+func (*DeviceHeartbeat) OutputsWarpMessage() bool {
+	return false
+}
+
+func (h *DeviceHeartbeat) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	data, output := getProjectDevice(ctx, mu, h.Project, h.Device)
+	if output != nil {
+		return false, DeviceHeartbeatComputeUnits, output, nil, nil
+	}
+	// Unlike status reports, heartbeats are only accepted from the device
+	// itself
+	if actor.Actor() != auth.NewED25519Address(data.PublicKey) {
+		return false, DeviceHeartbeatComputeUnits, OutputNotDeviceKey, nil, nil
+	}
+	version, err := storage.ParseSemVer(string(h.Version))
+	if err != nil {
+		return false, DeviceHeartbeatComputeUnits, OutputDeviceVersionInvalid, nil, nil
+	}
+	if h.Update == ids.Empty {
+		return true, DeviceHeartbeatComputeUnits, nil, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, h.Update)
+	if err != nil {
+		return false, DeviceHeartbeatComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, DeviceHeartbeatComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != h.Project {
+		return false, DeviceHeartbeatComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if storage.CompareSemVer(version, update.UpdateVersion) != 0 {
+		return false, DeviceHeartbeatComputeUnits, OutputHeartbeatVersionMismatch, nil, nil
+	}
+	return true, DeviceHeartbeatComputeUnits, nil, nil, nil
+}
+
+func (*DeviceHeartbeat) MaxComputeUnits(chain.Rules) uint64 {
+	return DeviceHeartbeatComputeUnits
+}
+
+func (h *DeviceHeartbeat) Size() int {
+	return consts.IDLen*3 + codec.BytesLen(h.Version) + consts.Uint64Len
+}
+
+func (h *DeviceHeartbeat) Marshal(p *codec.Packer) {
+	p.PackID(h.Project)
+	p.PackID(h.Device)
+	p.PackID(h.Update)
+	p.PackBytes(h.Version)
+	p.PackUint64(h.Uptime)
+}
+
+func UnmarshalDeviceHeartbeat(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var heartbeat DeviceHeartbeat
+	p.UnpackID(true, &heartbeat.Project)
+	p.UnpackID(true, &heartbeat.Device)
+	p.UnpackID(false, &heartbeat.Update)
+	p.UnpackBytes(UpdateVersionUnits, true, &heartbeat.Version)
+	heartbeat.Uptime = p.UnpackUint64(false)
+	return &heartbeat, p.Err()
+}
+
+func (*DeviceHeartbeat) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*EditProject)(nil)
+
+// EditProject replaces the description and logo of a project. Every revision
+// is kept in the project history, see [storage.StoreProjectRevision].
+type EditProject struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	ProjectDescription []byte `json:"description"`
+	Logo               []byte `json:"url"`
+}
+
+func (*EditProject) GetTypeID() uint8 {
+	return editProjectID
+}
+
+func (e *EditProject) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(e.Project)),
+		string(storage.LegacyProjectKey(e.Project)),
+		string(storage.MaintainersKey(e.Project)),
+	}
+}
+
+func (*EditProject) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks}
+}
+
+func (*EditProject) OutputsWarpMessage() bool {
+	return false
+}
+
+func (e *EditProject) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if len(e.ProjectDescription) == 0 {
+		return false, EditProjectComputeUnits, OutputProjectDescriptionNotGiven, nil, nil
+	}
+	if output := authorizeProject(ctx, mu, e.Project, auth.Actor(), 0); output != nil {
+		return false, EditProjectComputeUnits, output, nil, nil
+	}
+	if err := storage.SetProjectMetadata(ctx, mu, e.Project, e.ProjectDescription, e.Logo); err != nil {
+		return false, EditProjectComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, EditProjectComputeUnits, nil, nil, nil
+}
+
+func (*EditProject) MaxComputeUnits(chain.Rules) uint64 {
+	return EditProjectComputeUnits
+}
+
+func (e *EditProject) Size() int {
+	return consts.IDLen + codec.BytesLen(e.ProjectDescription) + codec.BytesLen(e.Logo)
+}
+
+func (e *EditProject) Marshal(p *codec.Packer) {
+	p.PackID(e.Project)
+	p.PackBytes(e.ProjectDescription)
+	p.PackBytes(e.Logo)
+}
+
+func UnmarshalEditProject(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var edit EditProject
+	p.UnpackID(true, &edit.Project)
+	p.UnpackBytes(ProjectDescriptionUnits, true, &edit.ProjectDescription)
+	p.UnpackBytes(ProjectLogoUnits, false, &edit.Logo)
+	return &edit, p.Err()
+}
+
+func (*EditProject) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
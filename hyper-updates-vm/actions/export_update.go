@@ -0,0 +1,213 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ExportUpdate)(nil)
+
+// ExportUpdate emits a Warp message replicating a released update to
+// [DestinationProject] on another hyper-updates chain, see [ImportUpdate].
+type ExportUpdate struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// DestinationProject is the project on [Destination] the update is
+	// imported into, it must list [Project] as an import source.
+	DestinationProject ids.ID `json:"destination_project_id"`
+
+	Destination ids.ID `json:"destination"`
+}
+
+func (*ExportUpdate) GetTypeID() uint8 {
+	return exportUpdateID
+}
+
+func (e *ExportUpdate) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(e.Project)),
+		string(storage.LegacyProjectKey(e.Project)),
+		string(storage.MaintainersKey(e.Project)),
+		string(storage.UpdateKey(e.Update)),
+		string(storage.LegacyUpdateKey(e.Update)),
+		string(storage.RevocationKey(e.Update)),
+		string(storage.UpdateResultsKey(e.Update)),
+		string(storage.RolloutKey(e.Update)),
+		string(storage.ApprovalsKey(e.Update)),
+		string(storage.ManifestKey(e.Update)),
+		string(storage.ConstraintsKey(e.Update)),
+		string(storage.UpdateLicenseKey(e.Update)),
+		string(storage.UpdateOriginKey(e.Update)),
+	}
+}
+
+func (*ExportUpdate) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.ApprovalsChunks,
+		storage.ManifestChunks,
+		storage.ConstraintsChunks,
+		storage.UpdateLicenseChunks,
+		storage.UpdateOriginChunks,
+	}
+}
+
+func (*ExportUpdate) OutputsWarpMessage() bool {
+	return true
+}
+
+func (e *ExportUpdate) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if e.Destination == ids.Empty {
+		return false, ExportUpdateComputeUnits, OutputAnycast, nil, nil
+	}
+	if e.Destination == r.ChainID() {
+		return false, ExportUpdateComputeUnits, OutputWrongDestination, nil, nil
+	}
+	actor := auth.Actor()
+	if output := authorizeProject(ctx, mu, e.Project, actor, RoleRelease); output != nil {
+		return false, ExportUpdateComputeUnits, output, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ExportUpdateComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != e.Project {
+		return false, ExportUpdateComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if update.Revoked {
+		return false, ExportUpdateComputeUnits, OutputUpdateRevoked, nil, nil
+	}
+	exists, approvals, err := storage.GetApprovals(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && approvals.Draft() {
+		return false, ExportUpdateComputeUnits, OutputUpdateIsDraft, nil, nil
+	}
+
+	manifest, err := storage.GetManifest(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	// Devices must not skip the pass-through release, which does not exist
+	// on the destination
+	constraints, err := storage.GetConstraints(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if constraints.PassThrough != ids.Empty {
+		return false, ExportUpdateComputeUnits, OutputExportPassThrough, nil, nil
+	}
+	license, err := storage.GetUpdateLicense(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+
+	// Destinations only trust the chain that signs the message to vouch for
+	// its own releases, imported updates are exported from where they were
+	// published
+	origin, err := storage.GetUpdateOrigin(ctx, mu, e.Update)
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !origin.Empty() {
+		return false, ExportUpdateComputeUnits, OutputExportImported, nil, nil
+	}
+
+	wu := &WarpUpdate{
+		Exporter:             actor,
+		Project:              e.DestinationProject,
+		UpdateExecutableHash: update.UpdateExecutableHash,
+		UpdateIPFSUrl:        update.UpdateIPFSUrl,
+		ForDeviceName:        update.ForDeviceName,
+		UpdateVersion:        []byte(update.UpdateVersion.String()),
+		Channel:              update.Channel,
+		RolloutPercentage:    update.RolloutPercentage,
+		Signature:            update.Signature,
+		Manifest:             manifest,
+		Constraints:          constraints,
+		NotBefore:            update.NotBefore,
+		ExpiresAt:            update.ExpiresAt,
+		LicenseMinBalance:    license.MinBalance,
+		Origin: storage.UpdateOrigin{
+			SourceChainID: r.ChainID(),
+			SourceTxID:    e.Update,
+			SourceProject: e.Project,
+		},
+		TxID:               txID,
+		DestinationChainID: e.Destination,
+	}
+	payload, err := wu.Marshal()
+	if err != nil {
+		return false, ExportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	wm := &warp.UnsignedMessage{
+		// NetworkID + SourceChainID is populated by hypersdk
+		Payload: payload,
+	}
+	return true, ExportUpdateComputeUnits, nil, wm, nil
+}
+
+func (*ExportUpdate) MaxComputeUnits(chain.Rules) uint64 {
+	return ExportUpdateComputeUnits
+}
+
+func (*ExportUpdate) Size() int {
+	return consts.IDLen * 4
+}
+
+func (e *ExportUpdate) Marshal(p *codec.Packer) {
+	p.PackID(e.Project)
+	p.PackID(e.Update)
+	p.PackID(e.DestinationProject)
+	p.PackID(e.Destination)
+}
+
+func UnmarshalExportUpdate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var export ExportUpdate
+	p.UnpackID(true, &export.Project)
+	p.UnpackID(true, &export.Update)
+	p.UnpackID(true, &export.DestinationProject)
+	p.UnpackID(true, &export.Destination)
+	return &export, p.Err()
+}
+
+func (*ExportUpdate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
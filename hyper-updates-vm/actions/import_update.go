@@ -0,0 +1,286 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+	"errors"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ImportUpdate)(nil)
+
+// ImportUpdate publishes an update exported from another hyper-updates chain
+// with [ExportUpdate]. It can be submitted by anyone, the message must be
+// signed by the chain the update was published on and the destination project
+// must list the source project with [AddImportSource]. The imported update
+// goes through the same checks as [CreateUpdate] and remembers its origin.
+type ImportUpdate struct {
+	// warpUpdate is parsed from the inner *warp.Message
+	warpUpdate *WarpUpdate
+
+	// warpMessage is the full *warp.Message parsed from [chain.Transaction]
+	warpMessage *warp.Message
+}
+
+func (*ImportUpdate) GetTypeID() uint8 {
+	return importUpdateID
+}
+
+func (i *ImportUpdate) StateKeys(_ chain.Auth, txID ids.ID) []string {
+	project := i.warpUpdate.Project
+	return []string{
+		string(storage.UpdateKey(txID)),
+		string(storage.RolloutKey(txID)),
+		string(storage.ManifestKey(txID)),
+		string(storage.ConstraintsKey(txID)),
+		string(storage.ApprovalsKey(txID)),
+		string(storage.UpdateLicenseKey(txID)),
+		string(storage.UpdateOriginKey(txID)),
+		string(storage.ImportedUpdateKey(i.warpUpdate.Origin)),
+		string(storage.ProjectKey(project)),
+		string(storage.LegacyProjectKey(project)),
+		string(storage.ImportSourcesKey(project)),
+		string(storage.LatestUpdateKey(project, i.warpUpdate.ForDeviceName, i.warpUpdate.Channel)),
+		string(storage.SigningKeysKey(project)),
+		string(storage.ApprovalThresholdKey(project)),
+		string(storage.LicenseAssetKey(project)),
+	}
+}
+
+func (*ImportUpdate) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.UpdateChunks,
+		storage.RolloutChunks,
+		storage.ManifestChunks,
+		storage.ConstraintsChunks,
+		storage.ApprovalsChunks,
+		storage.UpdateLicenseChunks,
+		storage.UpdateOriginChunks,
+		storage.ImportedUpdateChunks,
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.ImportSourcesChunks,
+		storage.LatestUpdateChunks,
+		storage.SigningKeysChunks,
+		storage.ThresholdChunks,
+		storage.LicenseAssetChunks,
+	}
+}
+
+func (*ImportUpdate) OutputsWarpMessage() bool {
+	return false
+}
+
+func (i *ImportUpdate) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	_ chain.Auth,
+	txID ids.ID,
+	warpVerified bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if !warpVerified {
+		return false, ImportUpdateComputeUnits, OutputWarpVerificationFailed, nil, nil
+	}
+	w := i.warpUpdate
+	if w.DestinationChainID != r.ChainID() {
+		return false, ImportUpdateComputeUnits, OutputInvalidDestination, nil, nil
+	}
+	// Only the validators of the origin chain can vouch for its releases, and
+	// only for source projects the destination owner trusts. The exporter is
+	// an address of another chain and proves nothing here.
+	if i.warpMessage.SourceChainID != w.Origin.SourceChainID {
+		return false, ImportUpdateComputeUnits, OutputWarpSourceMismatch, nil, nil
+	}
+	exists, _, err := storage.GetProject(ctx, mu, w.Project)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ImportUpdateComputeUnits, OutputProjectNotFound, nil, nil
+	}
+	sources, err := storage.GetImportSources(ctx, mu, w.Project)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	origin := storage.ImportSource{SourceChainID: w.Origin.SourceChainID, SourceProject: w.Origin.SourceProject}
+	trusted := false
+	for _, source := range sources {
+		if source == origin {
+			trusted = true
+			break
+		}
+	}
+	if !trusted {
+		return false, ImportUpdateComputeUnits, OutputImportSourceUntrusted, nil, nil
+	}
+	exists, _, err = storage.GetImportedUpdate(ctx, mu, w.Origin)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists {
+		return false, ImportUpdateComputeUnits, OutputUpdateAlreadyImported, nil, nil
+	}
+
+	// The source chain checked the record when it was published, but the
+	// destination may run different rules
+	if digest, err := storage.ParseDigest(w.UpdateExecutableHash); err != nil || digest.Weak() {
+		return false, ImportUpdateComputeUnits, OutputUpdateDigestInvalid, nil, nil
+	}
+	version, err := storage.ParseSemVer(string(w.UpdateVersion))
+	if err != nil {
+		return false, ImportUpdateComputeUnits, OutputUpdateVersionInvalid, nil, nil
+	}
+	if w.Channel > MaxChannel {
+		return false, ImportUpdateComputeUnits, OutputChannelInvalid, nil, nil
+	}
+	if w.RolloutPercentage > storage.MaxRolloutPercentage {
+		return false, ImportUpdateComputeUnits, OutputRolloutPercentageInvalid, nil, nil
+	}
+	signedDigest := w.UpdateExecutableHash
+	if len(w.Manifest) > 0 {
+		if err := w.Manifest.Validate(); err != nil {
+			return false, ImportUpdateComputeUnits, OutputManifestInvalid, nil, nil
+		}
+		signedDigest = w.Manifest.Digest().Bytes()
+	}
+	if err := w.Constraints.Validate(); err != nil || w.Constraints.PassThrough != ids.Empty {
+		return false, ImportUpdateComputeUnits, OutputConstraintsInvalid, nil, nil
+	}
+	window := storage.UpdateWindow{NotBefore: w.NotBefore, ExpiresAt: w.ExpiresAt}
+	if err := window.Validate(); err != nil {
+		return false, ImportUpdateComputeUnits, OutputUpdateWindowInvalid, nil, nil
+	}
+	if errors.Is(window.Check(timestamp), storage.ErrUpdateExpired) {
+		return false, ImportUpdateComputeUnits, OutputUpdateWindowExpired, nil, nil
+	}
+
+	// Premium updates stay premium, licensed in the asset of the destination
+	// project
+	var license storage.UpdateLicense
+	if w.LicenseMinBalance > 0 {
+		asset, err := storage.GetLicenseAsset(ctx, mu, w.Project)
+		if err != nil {
+			return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if asset == ids.Empty {
+			return false, ImportUpdateComputeUnits, OutputLicenseAssetNotSet, nil, nil
+		}
+		license = storage.UpdateLicense{Asset: asset, MinBalance: w.LicenseMinBalance}
+	}
+
+	// Vendors sign releases for the project they publish them in, a
+	// destination project with signing keys must trust the key that signed
+	// the release on the source chain
+	keys, err := storage.GetSigningKeys(ctx, mu, w.Project)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if len(keys) > 0 {
+		if len(w.Signature) == 0 {
+			return false, ImportUpdateComputeUnits, OutputUpdateSignatureMissing, nil, nil
+		}
+		msg := storage.UpdateSigningMessage(w.Origin.SourceProject, version, w.ForDeviceName, signedDigest)
+		if _, ok := storage.VerifyUpdateSignature(keys, msg, w.Signature); !ok {
+			return false, ImportUpdateComputeUnits, OutputUpdateSignatureInvalid, nil, nil
+		}
+	}
+
+	exists, _, latestVersion, err := storage.GetLatestUpdate(ctx, mu, w.Project, w.ForDeviceName, w.Channel)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && storage.CompareSemVer(version, latestVersion) <= 0 {
+		return false, ImportUpdateComputeUnits, OutputUpdateVersionNotIncreasing, nil, nil
+	}
+
+	if err := storage.SetUpdate(ctx, mu, txID, []byte(w.Project.String()), w.UpdateExecutableHash, w.UpdateIPFSUrl, w.ForDeviceName, version, w.Channel, w.Signature, storage.UpdatePatch{}, window); err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetRollout(ctx, mu, txID, w.RolloutPercentage); err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if len(w.Manifest) > 0 {
+		if err := storage.SetManifest(ctx, mu, txID, w.Manifest); err != nil {
+			return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if !w.Constraints.Empty() {
+		if err := storage.SetConstraints(ctx, mu, txID, w.Constraints); err != nil {
+			return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if !license.Empty() {
+		if err := storage.SetUpdateLicense(ctx, mu, txID, license); err != nil {
+			return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+	if err := storage.SetUpdateOrigin(ctx, mu, txID, w.Origin); err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+
+	// Imports are releases like any other, projects requiring sign-offs
+	// receive them as drafts. The exporter is recorded as the author but is
+	// not required to be a maintainer here.
+	threshold, err := storage.GetApprovalThreshold(ctx, mu, w.Project)
+	if err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if threshold > 0 {
+		if err := storage.SetApprovals(ctx, mu, txID, storage.UpdateApprovals{
+			Threshold: threshold,
+			Author:    w.Exporter,
+		}); err != nil {
+			return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, ImportUpdateComputeUnits, nil, nil, nil
+	}
+	if err := storage.SetLatestUpdate(ctx, mu, w.Project, w.ForDeviceName, w.Channel, txID, version); err != nil {
+		return false, ImportUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ImportUpdateComputeUnits, nil, nil, nil
+}
+
+func (*ImportUpdate) MaxComputeUnits(chain.Rules) uint64 {
+	return ImportUpdateComputeUnits
+}
+
+func (*ImportUpdate) Size() int {
+	return 0
+}
+
+// The update is carried by the warp message, there is nothing action specific
+// to encode.
+func (*ImportUpdate) Marshal(*codec.Packer) {}
+
+func UnmarshalImportUpdate(p *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var (
+		imp ImportUpdate
+		err error
+	)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	imp.warpMessage = wm
+	imp.warpUpdate, err = UnmarshalWarpUpdate(imp.warpMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &imp, nil
+}
+
+func (*ImportUpdate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+// testRules runs actions on [chainID].
+type testRules struct {
+	chain.Rules
+	chainID ids.ID
+}
+
+func (r testRules) ChainID() ids.ID { return r.chainID }
+
+// testImport returns the ImportUpdate of [w] received from [sourceChainID].
+func testImport(t *testing.T, sourceChainID ids.ID, w *WarpUpdate) *ImportUpdate {
+	t.Helper()
+	payload, err := w.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &warp.Message{UnsignedMessage: warp.UnsignedMessage{SourceChainID: sourceChainID, Payload: payload}}
+	action, err := UnmarshalImportUpdate(codec.NewReader(nil, 0), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return action.(*ImportUpdate)
+}
+
+func TestImportUpdate(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	sourceChainID := ids.GenerateTestID()
+	sourceProject := ids.GenerateTestID()
+	device := []byte("thermostat")
+	version := storage.SemVer{Major: 1, Minor: 2}
+	digest := storage.Digest{Algorithm: storage.DigestSHA256, Sum: make([]byte, 32)}.Bytes()
+
+	priv, err := ed25519.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(project ids.ID) []byte {
+		sig := ed25519.Sign(storage.UpdateSigningMessage(project, version, device, digest), priv)
+		return sig[:]
+	}
+
+	tests := []struct {
+		name       string
+		sourceID   ids.ID // chain the message is signed by
+		sources    []storage.ImportSource
+		keys       bool
+		signature  func(project ids.ID) []byte // signs for the destination project
+		unverified bool
+		otherDest  bool
+		output     []byte
+	}{
+		{name: "trusted source"},
+		{
+			name:      "signed by the source project",
+			keys:      true,
+			signature: func(ids.ID) []byte { return sign(sourceProject) },
+		},
+		{
+			name:      "signed for another project",
+			keys:      true,
+			signature: sign,
+			output:    OutputUpdateSignatureInvalid,
+		},
+		{name: "unsigned", keys: true, output: OutputUpdateSignatureMissing},
+		{
+			name:    "untrusted source chain",
+			sources: []storage.ImportSource{{SourceChainID: ids.GenerateTestID(), SourceProject: sourceProject}},
+			output:  OutputImportSourceUntrusted,
+		},
+		{
+			name:    "untrusted source project",
+			sources: []storage.ImportSource{{SourceChainID: sourceChainID, SourceProject: ids.GenerateTestID()}},
+			output:  OutputImportSourceUntrusted,
+		},
+		{name: "no sources", sources: []storage.ImportSource{}, output: OutputImportSourceUntrusted},
+		// A chain can only vouch for updates published on itself
+		{name: "relayed by another chain", sourceID: ids.GenerateTestID(), output: OutputWarpSourceMismatch},
+		{name: "unverified", unverified: true, output: OutputWarpVerificationFailed},
+		{name: "other destination", otherDest: true, output: OutputInvalidDestination},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		mu := testState{}
+		owner := testAddress()
+		project := setTestProject(t, mu, owner)
+		sources := tt.sources
+		if sources == nil {
+			sources = []storage.ImportSource{{SourceChainID: sourceChainID, SourceProject: sourceProject}}
+		}
+		if err := storage.SetImportSources(ctx, mu, project, sources); err != nil {
+			t.Fatal(err)
+		}
+		if tt.keys {
+			if err := storage.SetSigningKeys(ctx, mu, project, []ed25519.PublicKey{priv.PublicKey()}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		w := &WarpUpdate{
+			Exporter:             testAddress(),
+			Project:              project,
+			UpdateExecutableHash: digest,
+			UpdateIPFSUrl:        []byte("https://ipfs.io/ipfs/cid"),
+			ForDeviceName:        device,
+			UpdateVersion:        []byte(version.String()),
+			RolloutPercentage:    storage.MaxRolloutPercentage,
+			Origin: storage.UpdateOrigin{
+				SourceChainID: sourceChainID,
+				SourceTxID:    ids.GenerateTestID(),
+				SourceProject: sourceProject,
+			},
+			TxID:               ids.GenerateTestID(),
+			DestinationChainID: chainID,
+		}
+		if tt.signature != nil {
+			w.Signature = tt.signature(project)
+		}
+		if tt.otherDest {
+			w.DestinationChainID = ids.GenerateTestID()
+		}
+		sourceID := tt.sourceID
+		if sourceID == ids.Empty {
+			sourceID = sourceChainID
+		}
+
+		imp := testImport(t, sourceID, w)
+		txID := ids.GenerateTestID()
+		success, _, output, _, err := imp.Execute(ctx, testRules{chainID: chainID}, mu, 1, testAuth{actor: owner}, txID, !tt.unverified)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if success != (tt.output == nil) || !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: Execute = %t, %q, want %q", tt.name, success, output, tt.output)
+			continue
+		}
+		if !success {
+			if exists, _, _ := storage.GetUpdate(ctx, mu, txID); exists {
+				t.Errorf("%s: rejected import stored an update", tt.name)
+			}
+			continue
+		}
+
+		exists, latest, _, err := storage.GetLatestUpdate(ctx, mu, project, device, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists || latest != txID {
+			t.Errorf("%s: latest update = %s, want %s", tt.name, latest, txID)
+		}
+		exists, imported, err := storage.GetImportedUpdate(ctx, mu, w.Origin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists || imported != txID {
+			t.Errorf("%s: imported update = %s, want %s", tt.name, imported, txID)
+		}
+
+		// Each release is imported once, even when relayed again
+		success, _, output, _, err = imp.Execute(ctx, testRules{chainID: chainID}, mu, 1, testAuth{actor: owner}, ids.GenerateTestID(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if success || !bytes.Equal(output, OutputUpdateAlreadyImported) {
+			t.Errorf("%s: second import = %t, %q, want %q", tt.name, success, output, OutputUpdateAlreadyImported)
+		}
+	}
+}
@@ -44,8 +44,100 @@ var (
 	OutputProjectInvalidOwner        = []byte("Project Owner Invalid format")
 
 	OutputProjectTxIdNotProvided          = []byte("Project Txid not provided")
+	OutputProjectTxIdInvalid              = []byte("Project Txid is not a valid id")
+	OutputProjectNotFound                 = []byte("Project not found")
+	OutputNotProjectOwner                 = []byte("Actor is not the Project Owner")
+	OutputNotProjectMaintainer            = []byte("Actor is not a Project Maintainer with the required role")
 	OutputUpdateExecutableHashNotProvided = []byte("Update Executable Hash not provided")
+	OutputUpdateDigestInvalid             = []byte("Update Executable Hash must be a SHA-256, SHA-512 or BLAKE3 digest")
 	OutputUpdateExecutableIPFSNotProvided = []byte("Update Executable IPFS url Not Provided")
 	OutputForDeviceNameNotProvided        = []byte("Update Device Name Not Provided")
 	OutputUpdateVersionNotProvided        = []byte("Update Version Not Provided")
+	OutputUpdateVersionInvalid            = []byte("Update Version is not a valid semantic version")
+	OutputUpdateVersionNotIncreasing      = []byte("Update Version must be greater than the latest release")
+	OutputChannelInvalid                  = []byte("Channel is invalid")
+	OutputPatchBaseNotProvided            = []byte("Patch requires a Base Update")
+	OutputPatchBaseNotFound               = []byte("Patch Base Update not found")
+	OutputPatchBaseMismatch               = []byte("Patch Base Update is for a different Project or Device")
+	OutputPatchBaseNotOlder               = []byte("Patch Base Update must be older than the Update")
+	OutputPatchDigestInvalid              = []byte("Patch Hash must be a SHA-256, SHA-512 or BLAKE3 digest")
+	OutputPatchIPFSNotProvided            = []byte("Patch IPFS url Not Provided")
+	OutputManifestInvalid                 = []byte("Manifest artifacts are invalid or not in install order")
+	OutputManifestAppMismatch             = []byte("Manifest app artifact must match the Update Executable")
+	OutputConstraintsInvalid              = []byte("Update Constraints are invalid")
+	OutputPassThroughNotFound             = []byte("Pass-through Update not found")
+	OutputPassThroughMismatch             = []byte("Pass-through Update is for a different Project or Device")
+	OutputPassThroughNotOlder             = []byte("Pass-through Update must be older than the Update")
+	OutputPassThroughRevoked              = []byte("Pass-through Update has been revoked")
+	OutputPassThroughExpired              = []byte("Pass-through Update has expired")
+	OutputUpdateWindowInvalid             = []byte("Update validity window is invalid")
+	OutputUpdateWindowExpired             = []byte("Update validity window has already ended")
+	OutputPassThroughDraft                = []byte("Pass-through Update has not been released")
+	OutputLicenseInvalid                  = []byte("Update license must have a minimum balance")
+	OutputLicenseNotProjectAsset          = []byte("Update license must be the Project license asset")
+	OutputRolloutPercentageInvalid        = []byte("Rollout percentage must be between 0 and 100")
+	OutputRolloutNotIncreasing            = []byte("Rollout percentage can only be raised")
+
+	OutputMaintainerRolesInvalid = []byte("Maintainer roles are invalid")
+	OutputMaintainerIsOwner      = []byte("Project Owner cannot be a Maintainer")
+	OutputMaintainerMissing      = []byte("Maintainer not found")
+	OutputTooManyMaintainers     = []byte("Project has too many Maintainers")
+
+	OutputSigningKeyInvalid      = []byte("Signing key is invalid")
+	OutputSigningKeyExists       = []byte("Signing key is already registered")
+	OutputSigningKeyMissing      = []byte("Signing key not found")
+	OutputTooManySigningKeys     = []byte("Project has too many Signing keys")
+	OutputUpdateSignatureMissing = []byte("Update must be signed by a Project Signing key")
+	OutputUpdateSignatureInvalid = []byte("Update signature does not match a Project Signing key")
+
+	OutputProposedOwnerIsOwner = []byte("Proposed Owner already owns the Project")
+	OutputNoPendingOwner       = []byte("Project has no pending Owner")
+	OutputNotPendingOwner      = []byte("Actor is not the pending Project Owner")
+
+	OutputUpdateNotFound         = []byte("Update not found")
+	OutputUpdateProjectMismatch  = []byte("Update does not belong to the Project")
+	OutputUpdateAlreadyRevoked   = []byte("Update is already revoked")
+	OutputRevokeReasonInvalid    = []byte("Revoke reason is invalid")
+	OutputUpdateRevoked          = []byte("Update is revoked")
+	OutputUpdateDeviceMismatch   = []byte("Update is for a different Device")
+	OutputUpdateAlreadyInChannel = []byte("Update is already in the Channel")
+	OutputUpdateChannelMismatch  = []byte("Update is in a different Channel")
+	OutputUpdateIsDraft          = []byte("Update is a draft awaiting approval")
+
+	OutputApprovalThresholdInvalid     = []byte("Approval threshold is invalid")
+	OutputApprovalThresholdUnreachable = []byte("Approval threshold exceeds the Approvers other than the author")
+	OutputOwnerNeededAsApprover        = []byte("Approval threshold needs the new Owner as an Approver")
+	OutputUpdateNotDraft               = []byte("Update is not awaiting approval")
+	OutputApproverIsAuthor             = []byte("Update author cannot approve their own Update")
+	OutputUpdateAlreadyApproved        = []byte("Update is already approved by the Approver")
+
+	OutputLicenseAssetWarped   = []byte("License asset cannot be a warped asset")
+	OutputLicenseAssetNotOwned = []byte("License asset must be owned by the Project Owner")
+	OutputLicenseAssetNotSet   = []byte("Project has no license asset for a licensed Update")
+
+	OutputExportPassThrough     = []byte("Update with a Pass-through Update cannot be exported")
+	OutputExportImported        = []byte("Imported Update cannot be exported again")
+	OutputUpdateAlreadyImported = []byte("Update is already imported")
+	OutputWarpSourceMismatch    = []byte("Warp source chain does not match the Update origin")
+	OutputImportSourceUntrusted = []byte("Update origin is not an Import source of the Project")
+
+	OutputImportSourceInvalid  = []byte("Import source is invalid")
+	OutputImportSourceExists   = []byte("Import source is already registered")
+	OutputImportSourceMissing  = []byte("Import source not found")
+	OutputTooManyImportSources = []byte("Project has too many Import sources")
+
+	OutputResultStatusInvalid      = []byte("Result status is invalid")
+	OutputResultErrorCodeOnSuccess = []byte("Successful result cannot carry an error code")
+	OutputUpdateAlreadyReported    = []byte("Device already reported a result for the Update")
+
+	OutputDeviceModelNotProvided  = []byte("Device Model not provided")
+	OutputDeviceVersionInvalid    = []byte("Device Version is not a valid semantic version")
+	OutputDeviceAlreadyRegistered = []byte("Device is already registered")
+	OutputDeviceNotFound          = []byte("Device not found")
+	OutputDeviceProjectMismatch   = []byte("Device does not belong to the Project")
+	OutputDeviceStatusInvalid     = []byte("Device status is invalid")
+	OutputDeviceDecommissioned    = []byte("Device is decommissioned")
+
+	OutputNotDeviceKey             = []byte("Actor is not the Device key")
+	OutputHeartbeatVersionMismatch = []byte("Heartbeat version does not match the running Update")
 )
@@ -0,0 +1,97 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+// authorizeProject returns the output to fail with if [actor] may not act on
+// [project] with [role], or nil if it may. The owner holds every role; a
+// [role] of 0 means the action is reserved for the owner.
+//
+// Callers must include [storage.ProjectKey], [storage.LegacyProjectKey] and
+// [storage.MaintainersKey] in their state keys.
+func authorizeProject(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+	actor codec.Address,
+	role uint8,
+) []byte {
+	exists, data, err := storage.GetProject(ctx, im, project)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if !exists {
+		return OutputProjectNotFound
+	}
+	if data.ProjectOwner == actor {
+		return nil
+	}
+	if role == 0 {
+		return OutputNotProjectOwner
+	}
+	maintainers, err := storage.GetMaintainers(ctx, im, project)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	for _, m := range maintainers {
+		if m.Address == actor && m.Roles&role == role {
+			return nil
+		}
+	}
+	return OutputNotProjectMaintainer
+}
+
+// getProjectDevice loads [device] and makes sure it is an active member of
+// [project]. It returns the output to fail with if not.
+//
+// Callers must include [storage.DeviceKey] in their state keys.
+func getProjectDevice(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+	device ids.ID,
+) (storage.DeviceData, []byte) {
+	exists, data, err := storage.GetDevice(ctx, im, device)
+	if err != nil {
+		return storage.DeviceData{}, utils.ErrBytes(err)
+	}
+	if !exists {
+		return storage.DeviceData{}, OutputDeviceNotFound
+	}
+	if data.Project != project {
+		return storage.DeviceData{}, OutputDeviceProjectMismatch
+	}
+	if data.Status == DeviceStatusDecommissioned {
+		return storage.DeviceData{}, OutputDeviceDecommissioned
+	}
+	return data, nil
+}
+
+// checkApprovers returns [OutputApprovalThresholdUnreachable] if [maintainers]
+// can't sign off on updates of [project] at [threshold], or nil if they can.
+// Whoever publishes an update can't approve it; with the owner and every
+// approving maintainer able to publish, that leaves one approver per
+// maintainer holding [RoleApprove].
+func checkApprovers(maintainers []storage.Maintainer, owner codec.Address, threshold uint8) []byte {
+	approvers := 0
+	for _, m := range maintainers {
+		if m.Address != owner && m.Roles&RoleApprove == RoleApprove {
+			approvers++
+		}
+	}
+	if int(threshold) > approvers {
+		return OutputApprovalThresholdUnreachable
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+// testState is an in-memory [state.Mutable].
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s testState) Insert(_ context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s testState) Remove(_ context.Context, key []byte) error {
+	delete(s, string(key))
+	return nil
+}
+
+func testAddress() codec.Address {
+	return codec.CreateAddress(0, ids.GenerateTestID())
+}
+
+// setTestProject stores a project owned by [owner] with [maintainers].
+func setTestProject(t *testing.T, mu testState, owner codec.Address, maintainers ...storage.Maintainer) ids.ID {
+	t.Helper()
+	ctx := context.Background()
+	project := ids.GenerateTestID()
+	if err := storage.SetProject(ctx, mu, project, []byte("thermostat"), []byte("firmware"), owner, []byte("logo")); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.SetMaintainers(ctx, mu, project, maintainers); err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+func TestAuthorizeProject(t *testing.T) {
+	mu := testState{}
+	owner := testAddress()
+	releaser := testAddress()
+	yanker := testAddress()
+	project := setTestProject(
+		t,
+		mu,
+		owner,
+		storage.Maintainer{Address: releaser, Roles: RoleRelease | RoleEditMetadata},
+		storage.Maintainer{Address: yanker, Roles: RoleYank},
+	)
+
+	tests := []struct {
+		name    string
+		project ids.ID
+		actor   codec.Address
+		role    uint8
+		output  []byte
+	}{
+		{name: "owner only", project: project, actor: owner},
+		{name: "owner holds every role", project: project, actor: owner, role: AllRoles},
+		{name: "maintainer with role", project: project, actor: releaser, role: RoleRelease},
+		{name: "maintainer with roles", project: project, actor: releaser, role: RoleRelease | RoleEditMetadata},
+		{name: "maintainer without role", project: project, actor: yanker, role: RoleRelease, output: OutputNotProjectMaintainer},
+		{name: "maintainer with some roles", project: project, actor: releaser, role: RoleRelease | RoleYank, output: OutputNotProjectMaintainer},
+		{name: "maintainer on owner action", project: project, actor: releaser, output: OutputNotProjectOwner},
+		{name: "stranger", project: project, actor: testAddress(), role: RoleRelease, output: OutputNotProjectMaintainer},
+		{name: "stranger on owner action", project: project, actor: testAddress(), output: OutputNotProjectOwner},
+		{name: "unknown project", project: ids.GenerateTestID(), actor: owner, output: OutputProjectNotFound},
+	}
+	for _, tt := range tests {
+		output := authorizeProject(context.Background(), mu, tt.project, tt.actor, tt.role)
+		if !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: output = %q, want %q", tt.name, output, tt.output)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*PromoteUpdate)(nil)
+
+// PromoteUpdate moves an existing update to another channel, the binary is
+// not uploaded again. If the update was the latest of its old channel, that
+// channel is left without a latest update: an update is only ever latest on
+// the channel it is in. The channel keeps the version of the update, later
+// releases to it must still be newer.
+type PromoteUpdate struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// ForDeviceName must match the update, it selects the latest update
+	// pointer to move.
+	ForDeviceName []byte `json:"for_device_name"`
+
+	// Channel is the channel to move the update to.
+	Channel uint8 `json:"channel"`
+}
+
+func (*PromoteUpdate) GetTypeID() uint8 {
+	return promoteUpdateID
+}
+
+func (u *PromoteUpdate) StateKeys(chain.Auth, ids.ID) []string {
+	keys := []string{
+		string(storage.ProjectKey(u.Project)),
+		string(storage.LegacyProjectKey(u.Project)),
+		string(storage.MaintainersKey(u.Project)),
+		string(storage.UpdateKey(u.Update)),
+		string(storage.LegacyUpdateKey(u.Update)),
+		string(storage.RevocationKey(u.Update)),
+		string(storage.UpdateResultsKey(u.Update)),
+		string(storage.RolloutKey(u.Update)),
+		string(storage.ApprovalsKey(u.Update)),
+	}
+	// The channel the update leaves is only known once it is read
+	for channel := ChannelStable; channel <= MaxChannel; channel++ {
+		keys = append(keys, string(storage.LatestUpdateKey(u.Project, u.ForDeviceName, channel)))
+	}
+	return keys
+}
+
+func (*PromoteUpdate) StateKeysMaxChunks() []uint16 {
+	chunks := []uint16{
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.ApprovalsChunks,
+	}
+	for channel := ChannelStable; channel <= MaxChannel; channel++ {
+		chunks = append(chunks, storage.LatestUpdateChunks)
+	}
+	return chunks
+}
+
+func (*PromoteUpdate) OutputsWarpMessage() bool {
+	return false
+}
+
+func (u *PromoteUpdate) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if u.Channel > MaxChannel {
+		return false, PromoteUpdateComputeUnits, OutputChannelInvalid, nil, nil
+	}
+	if output := authorizeProject(ctx, mu, u.Project, auth.Actor(), RoleRelease); output != nil {
+		return false, PromoteUpdateComputeUnits, output, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, u.Update)
+	if err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, PromoteUpdateComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != u.Project {
+		return false, PromoteUpdateComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if !bytes.Equal(update.ForDeviceName, u.ForDeviceName) {
+		return false, PromoteUpdateComputeUnits, OutputUpdateDeviceMismatch, nil, nil
+	}
+	if update.Revoked {
+		return false, PromoteUpdateComputeUnits, OutputUpdateRevoked, nil, nil
+	}
+	if update.Channel == u.Channel {
+		return false, PromoteUpdateComputeUnits, OutputUpdateAlreadyInChannel, nil, nil
+	}
+	// Drafts reach a channel once they are approved
+	exists, approvals, err := storage.GetApprovals(ctx, mu, u.Update)
+	if err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && approvals.Draft() {
+		return false, PromoteUpdateComputeUnits, OutputUpdateIsDraft, nil, nil
+	}
+
+	// The target channel must still only move forward
+	exists, _, latestVersion, err := storage.GetLatestUpdate(ctx, mu, u.Project, u.ForDeviceName, u.Channel)
+	if err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && storage.CompareSemVer(update.UpdateVersion, latestVersion) <= 0 {
+		return false, PromoteUpdateComputeUnits, OutputUpdateVersionNotIncreasing, nil, nil
+	}
+
+	// Devices on the old channel must not be offered an update that moved
+	// away from it
+	exists, previous, _, err := storage.GetLatestUpdate(ctx, mu, u.Project, u.ForDeviceName, update.Channel)
+	if err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if exists && previous == u.Update {
+		if err := storage.ClearLatestUpdate(ctx, mu, u.Project, u.ForDeviceName, update.Channel, update.UpdateVersion); err != nil {
+			return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+	}
+
+	if err := storage.SetUpdateChannel(ctx, mu, u.Update, u.Channel); err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetLatestUpdate(ctx, mu, u.Project, u.ForDeviceName, u.Channel, u.Update, update.UpdateVersion); err != nil {
+		return false, PromoteUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, PromoteUpdateComputeUnits, nil, nil, nil
+}
+
+func (*PromoteUpdate) MaxComputeUnits(chain.Rules) uint64 {
+	return PromoteUpdateComputeUnits
+}
+
+func (u *PromoteUpdate) Size() int {
+	return consts.IDLen*2 + codec.BytesLen(u.ForDeviceName) + consts.Uint8Len
+}
+
+func (u *PromoteUpdate) Marshal(p *codec.Packer) {
+	p.PackID(u.Project)
+	p.PackID(u.Update)
+	p.PackBytes(u.ForDeviceName)
+	p.PackByte(u.Channel)
+}
+
+func UnmarshalPromoteUpdate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var promote PromoteUpdate
+	p.UnpackID(true, &promote.Project)
+	p.UnpackID(true, &promote.Update)
+	p.UnpackBytes(ForDeviceNameUnits, true, &promote.ForDeviceName)
+	promote.Channel = p.UnpackByte()
+	return &promote, p.Err()
+}
+
+func (*PromoteUpdate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
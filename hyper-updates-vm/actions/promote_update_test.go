@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestPromoteUpdateKeepsChannelVersion(t *testing.T) {
+	device := []byte("thermostat")
+	digest := storage.Digest{Algorithm: storage.DigestSHA256, Sum: make([]byte, 32)}.Bytes()
+
+	tests := []struct {
+		name    string
+		version string
+		output  []byte
+	}{
+		{name: "older release", version: "1.0.0", output: OutputUpdateVersionNotIncreasing},
+		{name: "promoted release", version: "1.1.0", output: OutputUpdateVersionNotIncreasing},
+		{name: "newer release", version: "1.2.0"},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		mu := testState{}
+		owner := testAddress()
+		project := setTestProject(t, mu, owner)
+		create := func(version string) (ids.ID, bool, []byte) {
+			txID := ids.GenerateTestID()
+			create := &CreateUpdate{
+				ProjectTxID:          []byte(project.String()),
+				UpdateExecutableHash: digest,
+				UpdateIPFSUrl:        []byte("https://ipfs.io/ipfs/cid"),
+				ForDeviceName:        device,
+				UpdateVersion:        []byte(version),
+				Channel:              ChannelBeta,
+				RolloutPercentage:    storage.MaxRolloutPercentage,
+			}
+			success, _, output, _, err := create.Execute(ctx, nil, mu, 1, testAuth{actor: owner}, txID, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return txID, success, output
+		}
+
+		update, success, output := create("1.1.0")
+		if !success {
+			t.Fatalf("%s: create = %q", tt.name, output)
+		}
+		promote := &PromoteUpdate{Project: project, Update: update, ForDeviceName: device, Channel: ChannelStable}
+		success, _, output, _, err := promote.Execute(ctx, nil, mu, 2, testAuth{actor: owner}, ids.Empty, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !success {
+			t.Fatalf("%s: promote = %q", tt.name, output)
+		}
+		exists, latest, _, err := storage.GetLatestUpdate(ctx, mu, project, device, ChannelStable)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists || latest != update {
+			t.Errorf("%s: stable latest update = %s, want %s", tt.name, latest, update)
+		}
+		// The promoted update is no longer offered on beta
+		_, latest, _, err = storage.GetLatestUpdate(ctx, mu, project, device, ChannelBeta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest != ids.Empty {
+			t.Errorf("%s: beta latest update = %s, want none", tt.name, latest)
+		}
+
+		next, success, output := create(tt.version)
+		if success != (tt.output == nil) || !bytes.Equal(output, tt.output) {
+			t.Errorf("%s: create %s = %t, %q, want %q", tt.name, tt.version, success, output, tt.output)
+			continue
+		}
+		if !success {
+			continue
+		}
+		_, latest, _, err = storage.GetLatestUpdate(ctx, mu, project, device, ChannelBeta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest != next {
+			t.Errorf("%s: beta latest update = %s, want %s", tt.name, latest, next)
+		}
+	}
+}
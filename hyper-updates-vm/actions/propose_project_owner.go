@@ -0,0 +1,117 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ProposeProjectOwner)(nil)
+
+// ProposeProjectOwner is the first step of an ownership transfer. Nothing
+// changes hands until [NewOwner] sends [AcceptProjectOwner].
+type ProposeProjectOwner struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// NewOwner replaces any earlier proposal, [codec.EmptyAddress] withdraws
+	// it.
+	NewOwner codec.Address `json:"new_owner"`
+}
+
+func (*ProposeProjectOwner) GetTypeID() uint8 {
+	return proposeProjectOwnerID
+}
+
+func (o *ProposeProjectOwner) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(o.Project)),
+		string(storage.LegacyProjectKey(o.Project)),
+		string(storage.MaintainersKey(o.Project)),
+		string(storage.PendingOwnerKey(o.Project)),
+		string(storage.ApprovalThresholdKey(o.Project)),
+	}
+}
+
+func (*ProposeProjectOwner) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.PendingOwnerChunks, storage.ThresholdChunks}
+}
+
+func (*ProposeProjectOwner) OutputsWarpMessage() bool {
+	return false
+}
+
+func (o *ProposeProjectOwner) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if output := authorizeProject(ctx, mu, o.Project, auth.Actor(), 0); output != nil {
+		return false, ProposeProjectOwnerComputeUnits, output, nil, nil
+	}
+	if o.NewOwner == auth.Actor() {
+		return false, ProposeProjectOwnerComputeUnits, OutputProposedOwnerIsOwner, nil, nil
+	}
+	if o.NewOwner == codec.EmptyAddress {
+		if err := storage.DeletePendingOwner(ctx, mu, o.Project); err != nil {
+			return false, ProposeProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, ProposeProjectOwnerComputeUnits, nil, nil, nil
+	}
+	// Fail early if [AcceptProjectOwner] would, see there
+	maintainers, err := storage.GetMaintainers(ctx, mu, o.Project)
+	if err != nil {
+		return false, ProposeProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	threshold, err := storage.GetApprovalThreshold(ctx, mu, o.Project)
+	if err != nil {
+		return false, ProposeProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if checkApprovers(maintainers, o.NewOwner, threshold) != nil {
+		return false, ProposeProjectOwnerComputeUnits, OutputOwnerNeededAsApprover, nil, nil
+	}
+	if err := storage.SetPendingOwner(ctx, mu, o.Project, o.NewOwner); err != nil {
+		return false, ProposeProjectOwnerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ProposeProjectOwnerComputeUnits, nil, nil, nil
+}
+
+func (*ProposeProjectOwner) MaxComputeUnits(chain.Rules) uint64 {
+	return ProposeProjectOwnerComputeUnits
+}
+
+func (*ProposeProjectOwner) Size() int {
+	return consts.IDLen + codec.AddressLen
+}
+
+func (o *ProposeProjectOwner) Marshal(p *codec.Packer) {
+	p.PackID(o.Project)
+	p.PackAddress(o.NewOwner)
+}
+
+func UnmarshalProposeProjectOwner(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var propose ProposeProjectOwner
+	p.UnpackID(true, &propose.Project)
+	p.UnpackAddress(&propose.NewOwner)
+	return &propose, p.Err()
+}
+
+func (*ProposeProjectOwner) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
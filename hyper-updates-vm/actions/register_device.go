@@ -0,0 +1,131 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RegisterDevice)(nil)
+
+type RegisterDevice struct {
+	// Project is the [TxID] that created the project the device runs.
+	Project ids.ID `json:"project_id"`
+
+	// PublicKey is the key the device signs with. The device is registered
+	// under [storage.DeviceID] of this key.
+	PublicKey ed25519.PublicKey `json:"public_key"`
+
+	// Model is the hardware model of the device.
+	Model []byte `json:"model"`
+
+	// Version is the semantic version the device currently runs.
+	Version []byte `json:"version"`
+}
+
+func (*RegisterDevice) GetTypeID() uint8 {
+	return registerDeviceID
+}
+
+func (r *RegisterDevice) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.MaintainersKey(r.Project)),
+		string(storage.DeviceKey(storage.DeviceID(r.PublicKey))),
+	}
+}
+
+func (*RegisterDevice) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.DeviceChunks}
+}
+
+func (*RegisterDevice) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RegisterDevice) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if len(r.Model) == 0 {
+		return false, RegisterDeviceComputeUnits, OutputDeviceModelNotProvided, nil, nil
+	}
+	version, err := storage.ParseSemVer(string(r.Version))
+	if err != nil {
+		return false, RegisterDeviceComputeUnits, OutputDeviceVersionInvalid, nil, nil
+	}
+	// The fleet inventory is part of the project metadata
+	if output := authorizeProject(ctx, mu, r.Project, auth.Actor(), RoleEditMetadata); output != nil {
+		return false, RegisterDeviceComputeUnits, output, nil, nil
+	}
+	device := storage.DeviceID(r.PublicKey)
+	exists, _, err := storage.GetDevice(ctx, mu, device)
+	if err != nil {
+		return false, RegisterDeviceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	// Decommissioned devices keep their record, so a key can never be reused
+	if exists {
+		return false, RegisterDeviceComputeUnits, OutputDeviceAlreadyRegistered, nil, nil
+	}
+	if err := storage.SetDevice(ctx, mu, storage.DeviceData{
+		ID:           device,
+		Project:      r.Project,
+		PublicKey:    r.PublicKey,
+		Status:       DeviceStatusActive,
+		Model:        r.Model,
+		Version:      version,
+		RegisteredAt: timestamp,
+		UpdatedAt:    timestamp,
+	}); err != nil {
+		return false, RegisterDeviceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RegisterDeviceComputeUnits, nil, nil, nil
+}
+
+func (*RegisterDevice) MaxComputeUnits(chain.Rules) uint64 {
+	return RegisterDeviceComputeUnits
+}
+
+func (r *RegisterDevice) Size() int {
+	return consts.IDLen + ed25519.PublicKeyLen + codec.BytesLen(r.Model) + codec.BytesLen(r.Version)
+}
+
+func (r *RegisterDevice) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackFixedBytes(r.PublicKey[:])
+	p.PackBytes(r.Model)
+	p.PackBytes(r.Version)
+}
+
+func UnmarshalRegisterDevice(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var register RegisterDevice
+	p.UnpackID(true, &register.Project)
+	pk := register.PublicKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &pk)
+	p.UnpackBytes(DeviceModelUnits, true, &register.Model)
+	p.UnpackBytes(UpdateVersionUnits, true, &register.Version)
+	return &register, p.Err()
+}
+
+func (*RegisterDevice) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
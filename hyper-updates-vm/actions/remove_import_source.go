@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RemoveImportSource)(nil)
+
+// RemoveImportSource stops trusting a project on another chain, updates
+// already imported from it are kept.
+type RemoveImportSource struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// SourceChainID and SourceProject identify the source to remove.
+	SourceChainID ids.ID `json:"source_chain_id"`
+	SourceProject ids.ID `json:"source_project_id"`
+}
+
+func (*RemoveImportSource) GetTypeID() uint8 {
+	return removeImportSourceID
+}
+
+func (r *RemoveImportSource) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.ImportSourcesKey(r.Project)),
+	}
+}
+
+func (*RemoveImportSource) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.ImportSourcesChunks}
+}
+
+func (*RemoveImportSource) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RemoveImportSource) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Only the owner may decide which chains can publish into the project
+	if output := authorizeProject(ctx, mu, r.Project, auth.Actor(), 0); output != nil {
+		return false, RemoveImportSourceComputeUnits, output, nil, nil
+	}
+	sources, err := storage.GetImportSources(ctx, mu, r.Project)
+	if err != nil {
+		return false, RemoveImportSourceComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	source := storage.ImportSource{SourceChainID: r.SourceChainID, SourceProject: r.SourceProject}
+	for i, s := range sources {
+		if s != source {
+			continue
+		}
+		sources = append(sources[:i], sources[i+1:]...)
+		if err := storage.SetImportSources(ctx, mu, r.Project, sources); err != nil {
+			return false, RemoveImportSourceComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, RemoveImportSourceComputeUnits, nil, nil, nil
+	}
+	return false, RemoveImportSourceComputeUnits, OutputImportSourceMissing, nil, nil
+}
+
+func (*RemoveImportSource) MaxComputeUnits(chain.Rules) uint64 {
+	return RemoveImportSourceComputeUnits
+}
+
+func (*RemoveImportSource) Size() int {
+	return consts.IDLen * 3
+}
+
+func (r *RemoveImportSource) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackID(r.SourceChainID)
+	p.PackID(r.SourceProject)
+}
+
+func UnmarshalRemoveImportSource(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var remove RemoveImportSource
+	p.UnpackID(true, &remove.Project)
+	p.UnpackID(true, &remove.SourceChainID)
+	p.UnpackID(true, &remove.SourceProject)
+	return &remove, p.Err()
+}
+
+func (*RemoveImportSource) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RemoveMaintainer)(nil)
+
+type RemoveMaintainer struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// Maintainer is the address losing all of its roles.
+	Maintainer codec.Address `json:"maintainer"`
+}
+
+func (*RemoveMaintainer) GetTypeID() uint8 {
+	return removeMaintainerID
+}
+
+func (r *RemoveMaintainer) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.MaintainersKey(r.Project)),
+		string(storage.ApprovalThresholdKey(r.Project)),
+	}
+}
+
+func (*RemoveMaintainer) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.ThresholdChunks}
+}
+
+func (*RemoveMaintainer) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RemoveMaintainer) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Only the owner may change who maintains a project
+	if output := authorizeProject(ctx, mu, r.Project, auth.Actor(), 0); output != nil {
+		return false, RemoveMaintainerComputeUnits, output, nil, nil
+	}
+	maintainers, err := storage.GetMaintainers(ctx, mu, r.Project)
+	if err != nil {
+		return false, RemoveMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	for i, m := range maintainers {
+		if m.Address != r.Maintainer {
+			continue
+		}
+		maintainers = append(maintainers[:i], maintainers[i+1:]...)
+		threshold, err := storage.GetApprovalThreshold(ctx, mu, r.Project)
+		if err != nil {
+			return false, RemoveMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if output := checkApprovers(maintainers, auth.Actor(), threshold); output != nil {
+			return false, RemoveMaintainerComputeUnits, output, nil, nil
+		}
+		if err := storage.SetMaintainers(ctx, mu, r.Project, maintainers); err != nil {
+			return false, RemoveMaintainerComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, RemoveMaintainerComputeUnits, nil, nil, nil
+	}
+	return false, RemoveMaintainerComputeUnits, OutputMaintainerMissing, nil, nil
+}
+
+func (*RemoveMaintainer) MaxComputeUnits(chain.Rules) uint64 {
+	return RemoveMaintainerComputeUnits
+}
+
+func (*RemoveMaintainer) Size() int {
+	return consts.IDLen + codec.AddressLen
+}
+
+func (r *RemoveMaintainer) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackAddress(r.Maintainer)
+}
+
+func UnmarshalRemoveMaintainer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var remove RemoveMaintainer
+	p.UnpackID(true, &remove.Project)
+	p.UnpackAddress(&remove.Maintainer)
+	return &remove, p.Err()
+}
+
+func (*RemoveMaintainer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
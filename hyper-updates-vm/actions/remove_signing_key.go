@@ -0,0 +1,108 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RemoveSigningKey)(nil)
+
+// RemoveSigningKey retires a vendor code-signing key, updates already signed
+// with it are kept. Removing the last key stops signatures from being
+// required.
+type RemoveSigningKey struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// PublicKey is the key to remove.
+	PublicKey ed25519.PublicKey `json:"public_key"`
+}
+
+func (*RemoveSigningKey) GetTypeID() uint8 {
+	return removeSigningKeyID
+}
+
+func (r *RemoveSigningKey) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.SigningKeysKey(r.Project)),
+	}
+}
+
+func (*RemoveSigningKey) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.SigningKeysChunks}
+}
+
+func (*RemoveSigningKey) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RemoveSigningKey) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	// Only the owner may change who can vouch for a build
+	if output := authorizeProject(ctx, mu, r.Project, auth.Actor(), 0); output != nil {
+		return false, RemoveSigningKeyComputeUnits, output, nil, nil
+	}
+	keys, err := storage.GetSigningKeys(ctx, mu, r.Project)
+	if err != nil {
+		return false, RemoveSigningKeyComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	for i, key := range keys {
+		if key != r.PublicKey {
+			continue
+		}
+		keys = append(keys[:i], keys[i+1:]...)
+		if err := storage.SetSigningKeys(ctx, mu, r.Project, keys); err != nil {
+			return false, RemoveSigningKeyComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		return true, RemoveSigningKeyComputeUnits, nil, nil, nil
+	}
+	return false, RemoveSigningKeyComputeUnits, OutputSigningKeyMissing, nil, nil
+}
+
+func (*RemoveSigningKey) MaxComputeUnits(chain.Rules) uint64 {
+	return RemoveSigningKeyComputeUnits
+}
+
+func (*RemoveSigningKey) Size() int {
+	return consts.IDLen + ed25519.PublicKeyLen
+}
+
+func (r *RemoveSigningKey) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackFixedBytes(r.PublicKey[:])
+}
+
+func UnmarshalRemoveSigningKey(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var remove RemoveSigningKey
+	p.UnpackID(true, &remove.Project)
+	pk := remove.PublicKey[:] // avoid allocating additional memory
+	p.UnpackFixedBytes(ed25519.PublicKeyLen, &pk)
+	return &remove, p.Err()
+}
+
+func (*RemoveSigningKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
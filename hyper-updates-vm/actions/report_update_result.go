@@ -0,0 +1,178 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/auth"
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*ReportUpdateResult)(nil)
+
+// ReportUpdateResult is sent by a device, or the updates server on its
+// behalf, once an OTA attempt has finished. Each device reports once per
+// update, the updates server needs [RoleReport] on [Project] to report for
+// devices holding no key.
+type ReportUpdateResult struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// Device is the [storage.DeviceID] of the device that installed the
+	// update.
+	Device ids.ID `json:"device_id"`
+
+	// Status is one of the Result* codes.
+	Status uint8 `json:"status"`
+
+	// ErrorCode is the device specific failure code, 0 on success.
+	ErrorCode uint32 `json:"error_code"`
+}
+
+func (*ReportUpdateResult) GetTypeID() uint8 {
+	return reportUpdateResultID
+}
+
+func (r *ReportUpdateResult) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.MaintainersKey(r.Project)),
+		string(storage.DeviceKey(r.Device)),
+		string(storage.UpdateKey(r.Update)),
+		string(storage.LegacyUpdateKey(r.Update)),
+		string(storage.RevocationKey(r.Update)),
+		string(storage.UpdateResultsKey(r.Update)),
+		string(storage.RolloutKey(r.Update)),
+		string(storage.UpdateReportKey(r.Update, r.Device)),
+	}
+}
+
+func (*ReportUpdateResult) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.DeviceChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+		storage.UpdateReportChunks,
+	}
+}
+
+func (*ReportUpdateResult) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *ReportUpdateResult) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	actor chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if r.Status == 0 || r.Status > MaxResultStatus {
+		return false, ReportUpdateResultComputeUnits, OutputResultStatusInvalid, nil, nil
+	}
+	if r.Status == ResultSuccess && r.ErrorCode != 0 {
+		return false, ReportUpdateResultComputeUnits, OutputResultErrorCodeOnSuccess, nil, nil
+	}
+	device, output := getProjectDevice(ctx, mu, r.Project, r.Device)
+	if output != nil {
+		return false, ReportUpdateResultComputeUnits, output, nil, nil
+	}
+	// Devices may report on themselves, the updates server reports for those
+	// holding no key
+	if actor.Actor() != auth.NewED25519Address(device.PublicKey) {
+		if output := authorizeProject(ctx, mu, r.Project, actor.Actor(), RoleReport); output != nil {
+			return false, ReportUpdateResultComputeUnits, output, nil, nil
+		}
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, r.Update)
+	if err != nil {
+		return false, ReportUpdateResultComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, ReportUpdateResultComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	// Roles are granted per project, so the update must belong to [Project]
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != r.Project {
+		return false, ReportUpdateResultComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	reported, err := storage.HasUpdateReport(ctx, mu, r.Update, r.Device)
+	if err != nil {
+		return false, ReportUpdateResultComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if reported {
+		return false, ReportUpdateResultComputeUnits, OutputUpdateAlreadyReported, nil, nil
+	}
+
+	// A rolled back install is a failure as far as the counters are concerned
+	success, failure := update.SuccessCount, update.FailureCount
+	if r.Status == ResultSuccess {
+		success, err = smath.Add64(success, 1)
+	} else {
+		failure, err = smath.Add64(failure, 1)
+	}
+	if err != nil {
+		return false, ReportUpdateResultComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetUpdateResults(ctx, mu, r.Update, success, failure); err != nil {
+		return false, ReportUpdateResultComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if err := storage.SetUpdateReport(ctx, mu, r.Update, r.Device, r.Status, r.ErrorCode); err != nil {
+		return false, ReportUpdateResultComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, ReportUpdateResultComputeUnits, nil, nil, nil
+}
+
+func (*ReportUpdateResult) MaxComputeUnits(chain.Rules) uint64 {
+	return ReportUpdateResultComputeUnits
+}
+
+func (*ReportUpdateResult) Size() int {
+	return consts.IDLen*3 + consts.Uint8Len + consts.IntLen
+}
+
+func (r *ReportUpdateResult) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackID(r.Update)
+	p.PackID(r.Device)
+	p.PackByte(r.Status)
+	p.PackInt(int(r.ErrorCode))
+}
+
+func UnmarshalReportUpdateResult(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var report ReportUpdateResult
+	p.UnpackID(true, &report.Project)
+	p.UnpackID(true, &report.Update)
+	p.UnpackID(true, &report.Device)
+	report.Status = p.UnpackByte()
+	report.ErrorCode = uint32(p.UnpackInt(false))
+	return &report, p.Err()
+}
+
+func (*ReportUpdateResult) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*RevokeUpdate)(nil)
+
+// RevokeUpdate pulls a published update. The latest update pointer of its
+// channel is left in place so later releases must still be newer, devices
+// are offered the newest earlier release that is not revoked instead.
+type RevokeUpdate struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// Reason is one of the RevokeReason* codes.
+	Reason uint8 `json:"reason"`
+
+	// Note is a free-text explanation shown to operators.
+	Note []byte `json:"note"`
+}
+
+func (*RevokeUpdate) GetTypeID() uint8 {
+	return revokeUpdateID
+}
+
+func (r *RevokeUpdate) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(r.Project)),
+		string(storage.LegacyProjectKey(r.Project)),
+		string(storage.MaintainersKey(r.Project)),
+		string(storage.UpdateKey(r.Update)),
+		string(storage.LegacyUpdateKey(r.Update)),
+		string(storage.RevocationKey(r.Update)),
+		string(storage.UpdateResultsKey(r.Update)),
+		string(storage.RolloutKey(r.Update)),
+	}
+}
+
+func (*RevokeUpdate) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.UpdateChunks, storage.LegacyUpdateChunks, storage.RevocationChunks, storage.UpdateResultsChunks, storage.RolloutChunks}
+}
+
+func (*RevokeUpdate) OutputsWarpMessage() bool {
+	return false
+}
+
+func (r *RevokeUpdate) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if r.Reason == 0 || r.Reason > MaxRevokeReason {
+		return false, RevokeUpdateComputeUnits, OutputRevokeReasonInvalid, nil, nil
+	}
+	if output := authorizeProject(ctx, mu, r.Project, auth.Actor(), RoleYank); output != nil {
+		return false, RevokeUpdateComputeUnits, output, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, r.Update)
+	if err != nil {
+		return false, RevokeUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, RevokeUpdateComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	// Roles are granted per project, so the update must belong to [Project]
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != r.Project {
+		return false, RevokeUpdateComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if update.Revoked {
+		return false, RevokeUpdateComputeUnits, OutputUpdateAlreadyRevoked, nil, nil
+	}
+	if err := storage.SetRevocation(ctx, mu, r.Update, r.Reason, timestamp, r.Note); err != nil {
+		return false, RevokeUpdateComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, RevokeUpdateComputeUnits, nil, nil, nil
+}
+
+func (*RevokeUpdate) MaxComputeUnits(chain.Rules) uint64 {
+	return RevokeUpdateComputeUnits
+}
+
+func (r *RevokeUpdate) Size() int {
+	return consts.IDLen*2 + consts.Uint8Len + codec.BytesLen(r.Note)
+}
+
+func (r *RevokeUpdate) Marshal(p *codec.Packer) {
+	p.PackID(r.Project)
+	p.PackID(r.Update)
+	p.PackByte(r.Reason)
+	p.PackBytes(r.Note)
+}
+
+func UnmarshalRevokeUpdate(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var revoke RevokeUpdate
+	p.UnpackID(true, &revoke.Project)
+	p.UnpackID(true, &revoke.Update)
+	revoke.Reason = p.UnpackByte()
+	p.UnpackBytes(RevokeNoteUnits, false, &revoke.Note)
+	return &revoke, p.Err()
+}
+
+func (*RevokeUpdate) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetApprovalThreshold)(nil)
+
+// SetApprovalThreshold sets how many approvers must sign off on an update
+// before it is released. Approvers are the owner and maintainers holding
+// [RoleApprove], the threshold can't exceed the approvers left once the
+// author of an update is excluded. Updates created while the threshold is 0
+// are released immediately, changing the threshold does not affect existing
+// drafts.
+type SetApprovalThreshold struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	Threshold uint8 `json:"threshold"`
+}
+
+func (*SetApprovalThreshold) GetTypeID() uint8 {
+	return setApprovalThresholdID
+}
+
+func (s *SetApprovalThreshold) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(s.Project)),
+		string(storage.LegacyProjectKey(s.Project)),
+		string(storage.MaintainersKey(s.Project)),
+		string(storage.ApprovalThresholdKey(s.Project)),
+	}
+}
+
+func (*SetApprovalThreshold) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.ThresholdChunks}
+}
+
+func (*SetApprovalThreshold) OutputsWarpMessage() bool {
+	return false
+}
+
+func (s *SetApprovalThreshold) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if s.Threshold > storage.MaxApprovalThreshold {
+		return false, SetApprovalThresholdComputeUnits, OutputApprovalThresholdInvalid, nil, nil
+	}
+	// Only the owner may change the release policy
+	owner := auth.Actor()
+	if output := authorizeProject(ctx, mu, s.Project, owner, 0); output != nil {
+		return false, SetApprovalThresholdComputeUnits, output, nil, nil
+	}
+	maintainers, err := storage.GetMaintainers(ctx, mu, s.Project)
+	if err != nil {
+		return false, SetApprovalThresholdComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if output := checkApprovers(maintainers, owner, s.Threshold); output != nil {
+		return false, SetApprovalThresholdComputeUnits, output, nil, nil
+	}
+	if err := storage.SetApprovalThreshold(ctx, mu, s.Project, s.Threshold); err != nil {
+		return false, SetApprovalThresholdComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, SetApprovalThresholdComputeUnits, nil, nil, nil
+}
+
+func (*SetApprovalThreshold) MaxComputeUnits(chain.Rules) uint64 {
+	return SetApprovalThresholdComputeUnits
+}
+
+func (*SetApprovalThreshold) Size() int {
+	return consts.IDLen + consts.Uint8Len
+}
+
+func (s *SetApprovalThreshold) Marshal(p *codec.Packer) {
+	p.PackID(s.Project)
+	p.PackByte(s.Threshold)
+}
+
+func UnmarshalSetApprovalThreshold(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var set SetApprovalThreshold
+	p.UnpackID(true, &set.Project)
+	set.Threshold = p.UnpackByte()
+	return &set, p.Err()
+}
+
+func (*SetApprovalThreshold) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetLicenseAsset)(nil)
+
+// SetLicenseAsset designates the asset licenses of a project are issued in.
+// Licenses are minted to device addresses with [MintAsset], so the project
+// owner must own the asset. [ids.Empty] clears the license asset.
+type SetLicenseAsset struct {
+	// Project is the [TxID] that created the project.
+	Project ids.ID `json:"project_id"`
+
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+}
+
+func (*SetLicenseAsset) GetTypeID() uint8 {
+	return setLicenseAssetID
+}
+
+func (s *SetLicenseAsset) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(s.Project)),
+		string(storage.LegacyProjectKey(s.Project)),
+		string(storage.LicenseAssetKey(s.Project)),
+		string(storage.AssetKey(s.Asset)),
+	}
+}
+
+func (*SetLicenseAsset) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.LicenseAssetChunks, storage.AssetChunks}
+}
+
+func (*SetLicenseAsset) OutputsWarpMessage() bool {
+	return false
+}
+
+func (s *SetLicenseAsset) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	actor := auth.Actor()
+	if output := authorizeProject(ctx, mu, s.Project, actor, 0); output != nil {
+		return false, SetLicenseAssetComputeUnits, output, nil, nil
+	}
+	if s.Asset != ids.Empty {
+		exists, _, _, _, _, owner, isWarp, err := storage.GetAsset(ctx, mu, s.Asset)
+		if err != nil {
+			return false, SetLicenseAssetComputeUnits, utils.ErrBytes(err), nil, nil
+		}
+		if !exists {
+			return false, SetLicenseAssetComputeUnits, OutputAssetMissing, nil, nil
+		}
+		// Warped assets can't be minted on this chain
+		if isWarp {
+			return false, SetLicenseAssetComputeUnits, OutputLicenseAssetWarped, nil, nil
+		}
+		if owner != actor {
+			return false, SetLicenseAssetComputeUnits, OutputLicenseAssetNotOwned, nil, nil
+		}
+	}
+	if err := storage.SetLicenseAsset(ctx, mu, s.Project, s.Asset); err != nil {
+		return false, SetLicenseAssetComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, SetLicenseAssetComputeUnits, nil, nil, nil
+}
+
+func (*SetLicenseAsset) MaxComputeUnits(chain.Rules) uint64 {
+	return SetLicenseAssetComputeUnits
+}
+
+func (*SetLicenseAsset) Size() int {
+	return consts.IDLen * 2
+}
+
+func (s *SetLicenseAsset) Marshal(p *codec.Packer) {
+	p.PackID(s.Project)
+	p.PackID(s.Asset)
+}
+
+func UnmarshalSetLicenseAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var set SetLicenseAsset
+	p.UnpackID(true, &set.Project)
+	p.UnpackID(false, &set.Asset)
+	return &set, p.Err()
+}
+
+func (*SetLicenseAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*SetRolloutPercentage)(nil)
+
+// SetRolloutPercentage widens the share of the fleet an update is offered to.
+// Devices are bucketed with [storage.RolloutBucket], so raising the
+// percentage only ever adds devices. To pull an update back, revoke it.
+type SetRolloutPercentage struct {
+	// Project is the [TxID] that created the project the update belongs to.
+	Project ids.ID `json:"project_id"`
+
+	// Update is the [TxID] that created the update.
+	Update ids.ID `json:"update_id"`
+
+	// Percentage must be greater than the current rollout and at most 100.
+	Percentage uint8 `json:"percentage"`
+}
+
+func (*SetRolloutPercentage) GetTypeID() uint8 {
+	return setRolloutPercentageID
+}
+
+func (s *SetRolloutPercentage) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(s.Project)),
+		string(storage.LegacyProjectKey(s.Project)),
+		string(storage.MaintainersKey(s.Project)),
+		string(storage.UpdateKey(s.Update)),
+		string(storage.LegacyUpdateKey(s.Update)),
+		string(storage.RevocationKey(s.Update)),
+		string(storage.UpdateResultsKey(s.Update)),
+		string(storage.RolloutKey(s.Update)),
+	}
+}
+
+func (*SetRolloutPercentage) StateKeysMaxChunks() []uint16 {
+	return []uint16{
+		storage.ProjectChunks,
+		storage.LegacyProjectChunks,
+		storage.MaintainersChunks,
+		storage.UpdateChunks,
+		storage.LegacyUpdateChunks,
+		storage.RevocationChunks,
+		storage.UpdateResultsChunks,
+		storage.RolloutChunks,
+	}
+}
+
+func (*SetRolloutPercentage) OutputsWarpMessage() bool {
+	return false
+}
+
+func (s *SetRolloutPercentage) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	_ int64,
+	auth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if s.Percentage > storage.MaxRolloutPercentage {
+		return false, SetRolloutPercentageComputeUnits, OutputRolloutPercentageInvalid, nil, nil
+	}
+	if output := authorizeProject(ctx, mu, s.Project, auth.Actor(), RoleRelease); output != nil {
+		return false, SetRolloutPercentageComputeUnits, output, nil, nil
+	}
+	exists, update, err := storage.GetUpdate(ctx, mu, s.Update)
+	if err != nil {
+		return false, SetRolloutPercentageComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	if !exists {
+		return false, SetRolloutPercentageComputeUnits, OutputUpdateNotFound, nil, nil
+	}
+	project, err := ParseProjectID(update.ProjectTxID)
+	if err != nil || project != s.Project {
+		return false, SetRolloutPercentageComputeUnits, OutputUpdateProjectMismatch, nil, nil
+	}
+	if update.Revoked {
+		return false, SetRolloutPercentageComputeUnits, OutputUpdateRevoked, nil, nil
+	}
+	if s.Percentage <= update.RolloutPercentage {
+		return false, SetRolloutPercentageComputeUnits, OutputRolloutNotIncreasing, nil, nil
+	}
+	if err := storage.SetRollout(ctx, mu, s.Update, s.Percentage); err != nil {
+		return false, SetRolloutPercentageComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, SetRolloutPercentageComputeUnits, nil, nil, nil
+}
+
+func (*SetRolloutPercentage) MaxComputeUnits(chain.Rules) uint64 {
+	return SetRolloutPercentageComputeUnits
+}
+
+func (*SetRolloutPercentage) Size() int {
+	return consts.IDLen*2 + consts.Uint8Len
+}
+
+func (s *SetRolloutPercentage) Marshal(p *codec.Packer) {
+	p.PackID(s.Project)
+	p.PackID(s.Update)
+	p.PackByte(s.Percentage)
+}
+
+func UnmarshalSetRolloutPercentage(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var rollout SetRolloutPercentage
+	p.UnpackID(true, &rollout.Project)
+	p.UnpackID(true, &rollout.Update)
+	rollout.Percentage = p.UnpackByte()
+	return &rollout, p.Err()
+}
+
+func (*SetRolloutPercentage) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"context"
+
+	"hyper-updates/auth"
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+var _ chain.Action = (*UpdateDeviceStatus)(nil)
+
+type UpdateDeviceStatus struct {
+	// Project is the [TxID] that created the project the device runs.
+	Project ids.ID `json:"project_id"`
+
+	// Device is the [storage.DeviceID] of the device.
+	Device ids.ID `json:"device_id"`
+
+	// Status is one of the DeviceStatus* codes, except decommissioned.
+	Status uint8 `json:"status"`
+
+	// Version is the semantic version the device now runs. It is left
+	// unchanged if empty.
+	Version []byte `json:"version"`
+}
+
+func (*UpdateDeviceStatus) GetTypeID() uint8 {
+	return updateDeviceStatusID
+}
+
+func (u *UpdateDeviceStatus) StateKeys(chain.Auth, ids.ID) []string {
+	return []string{
+		string(storage.ProjectKey(u.Project)),
+		string(storage.LegacyProjectKey(u.Project)),
+		string(storage.MaintainersKey(u.Project)),
+		string(storage.DeviceKey(u.Device)),
+	}
+}
+
+func (*UpdateDeviceStatus) StateKeysMaxChunks() []uint16 {
+	return []uint16{storage.ProjectChunks, storage.LegacyProjectChunks, storage.MaintainersChunks, storage.DeviceChunks}
+}
+
+func (*UpdateDeviceStatus) OutputsWarpMessage() bool {
+	return false
+}
+
+func (u *UpdateDeviceStatus) Execute(
+	ctx context.Context,
+	_ chain.Rules,
+	mu state.Mutable,
+	timestamp int64,
+	actor chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (bool, uint64, []byte, *warp.UnsignedMessage, error) {
+	if u.Status == 0 || u.Status > MaxReportableDeviceStatus {
+		return false, UpdateDeviceStatusComputeUnits, OutputDeviceStatusInvalid, nil, nil
+	}
+	data, output := getProjectDevice(ctx, mu, u.Project, u.Device)
+	if output != nil {
+		return false, UpdateDeviceStatusComputeUnits, output, nil, nil
+	}
+	// Devices may report on themselves, anyone else needs project rights
+	if actor.Actor() != auth.NewED25519Address(data.PublicKey) {
+		if output := authorizeProject(ctx, mu, u.Project, actor.Actor(), RoleEditMetadata); output != nil {
+			return false, UpdateDeviceStatusComputeUnits, output, nil, nil
+		}
+	}
+	if len(u.Version) > 0 {
+		version, err := storage.ParseSemVer(string(u.Version))
+		if err != nil {
+			return false, UpdateDeviceStatusComputeUnits, OutputDeviceVersionInvalid, nil, nil
+		}
+		data.Version = version
+	}
+	data.Status = u.Status
+	data.UpdatedAt = timestamp
+	if err := storage.SetDevice(ctx, mu, data); err != nil {
+		return false, UpdateDeviceStatusComputeUnits, utils.ErrBytes(err), nil, nil
+	}
+	return true, UpdateDeviceStatusComputeUnits, nil, nil, nil
+}
+
+func (*UpdateDeviceStatus) MaxComputeUnits(chain.Rules) uint64 {
+	return UpdateDeviceStatusComputeUnits
+}
+
+func (u *UpdateDeviceStatus) Size() int {
+	return consts.IDLen*2 + consts.Uint8Len + codec.BytesLen(u.Version)
+}
+
+func (u *UpdateDeviceStatus) Marshal(p *codec.Packer) {
+	p.PackID(u.Project)
+	p.PackID(u.Device)
+	p.PackByte(u.Status)
+	p.PackBytes(u.Version)
+}
+
+func UnmarshalUpdateDeviceStatus(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var update UpdateDeviceStatus
+	p.UnpackID(true, &update.Project)
+	p.UnpackID(true, &update.Device)
+	update.Status = p.UnpackByte()
+	p.UnpackBytes(UpdateVersionUnits, false, &update.Version)
+	return &update, p.Err()
+}
+
+func (*UpdateDeviceStatus) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
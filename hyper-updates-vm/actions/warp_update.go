@@ -0,0 +1,133 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package actions
+
+import (
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// WarpUpdate replicates an update record to another hyper-updates chain.
+// Patches, pass-through releases and license assets reference records of
+// the source chain, so they are not carried.
+type WarpUpdate struct {
+	// Exporter released the update on the source chain. It only names the
+	// author of the draft on the destination, it grants no authority there.
+	Exporter codec.Address `json:"exporter"`
+
+	// Project is the project on the destination chain the update is imported
+	// into.
+	Project ids.ID `json:"project_id"`
+
+	UpdateExecutableHash []byte                    `json:"executable_hash"`
+	UpdateIPFSUrl        []byte                    `json:"executable_ipfs_url"`
+	ForDeviceName        []byte                    `json:"for_device_name"`
+	UpdateVersion        []byte                    `json:"version"`
+	Channel              uint8                     `json:"channel"`
+	RolloutPercentage    uint8                     `json:"rollout_percentage"`
+	Signature            []byte                    `json:"signature"`
+	Manifest             storage.Manifest          `json:"manifest"`
+	Constraints          storage.UpdateConstraints `json:"constraints"`
+	NotBefore            int64                     `json:"not_before"`
+	ExpiresAt            int64                     `json:"expires_at"`
+
+	// LicenseMinBalance is the license balance required on the source chain,
+	// the importing project must then have a license asset.
+	LicenseMinBalance uint64 `json:"license_min_balance"`
+
+	// Origin is where the update was published, it must be the chain that
+	// signed the message. Vendor signatures are made over its project.
+	Origin storage.UpdateOrigin `json:"origin"`
+
+	// TxID is the transaction that created this message. This is used to ensure
+	// there is WarpID uniqueness.
+	TxID ids.ID `json:"txID"`
+
+	// DestinationChainID is the destination of this update. We assume this
+	// must be populated (not anycast).
+	DestinationChainID ids.ID `json:"destinationChainID"`
+}
+
+func (w *WarpUpdate) size() int {
+	return codec.AddressLen + consts.IDLen +
+		codec.BytesLen(w.UpdateExecutableHash) +
+		codec.BytesLen(w.UpdateIPFSUrl) +
+		codec.BytesLen(w.ForDeviceName) +
+		codec.BytesLen(w.UpdateVersion) +
+		consts.Uint8Len*2 +
+		codec.BytesLen(w.Signature) +
+		w.Manifest.Size() +
+		w.Constraints.Size() +
+		consts.Int64Len*2 +
+		consts.Uint64Len +
+		consts.IDLen*3 +
+		consts.IDLen + consts.IDLen
+}
+
+func (w *WarpUpdate) Marshal() ([]byte, error) {
+	p := codec.NewWriter(w.size(), w.size())
+	p.PackAddress(w.Exporter)
+	p.PackID(w.Project)
+	p.PackBytes(w.UpdateExecutableHash)
+	p.PackBytes(w.UpdateIPFSUrl)
+	p.PackBytes(w.ForDeviceName)
+	p.PackBytes(w.UpdateVersion)
+	p.PackByte(w.Channel)
+	p.PackByte(w.RolloutPercentage)
+	p.PackBytes(w.Signature)
+	storage.PackManifest(p, w.Manifest)
+	storage.PackConstraints(p, w.Constraints)
+	p.PackInt64(w.NotBefore)
+	p.PackInt64(w.ExpiresAt)
+	p.PackUint64(w.LicenseMinBalance)
+	p.PackID(w.Origin.SourceChainID)
+	p.PackID(w.Origin.SourceTxID)
+	p.PackID(w.Origin.SourceProject)
+	p.PackID(w.TxID)
+	p.PackID(w.DestinationChainID)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalWarpUpdate(b []byte) (*WarpUpdate, error) {
+	var update WarpUpdate
+	p := codec.NewReader(b, consts.NetworkSizeLimit)
+	p.UnpackAddress(&update.Exporter)
+	p.UnpackID(true, &update.Project)
+	p.UnpackBytes(UpdateExecutableHashUnits, true, &update.UpdateExecutableHash)
+	p.UnpackBytes(UpdateExecutableIPFSUrl, true, &update.UpdateIPFSUrl)
+	p.UnpackBytes(ForDeviceNameUnits, true, &update.ForDeviceName)
+	p.UnpackBytes(UpdateVersionUnits, true, &update.UpdateVersion)
+	update.Channel = p.UnpackByte()
+	update.RolloutPercentage = p.UnpackByte()
+	p.UnpackBytes(UpdateSignatureUnits, false, &update.Signature)
+	manifest, err := storage.UnpackManifest(p)
+	if err != nil {
+		return nil, err
+	}
+	update.Manifest = manifest
+	constraints, err := storage.UnpackConstraints(p)
+	if err != nil {
+		return nil, err
+	}
+	update.Constraints = constraints
+	update.NotBefore = p.UnpackInt64(false)
+	update.ExpiresAt = p.UnpackInt64(false)
+	update.LicenseMinBalance = p.UnpackUint64(false)
+	p.UnpackID(true, &update.Origin.SourceChainID)
+	p.UnpackID(true, &update.Origin.SourceTxID)
+	p.UnpackID(true, &update.Origin.SourceProject)
+	p.UnpackID(true, &update.TxID)
+	p.UnpackID(true, &update.DestinationChainID)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &update, nil
+}
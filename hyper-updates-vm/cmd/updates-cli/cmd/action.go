@@ -492,28 +492,18 @@ var fillOrderCmd = &cobra.Command{
 	},
 }
 
-func performImport(
+// aggregateWarpSignature collects signatures of the warp message emitted by
+// [exportTxID] until they cover at least 80% of the stake. It returns a nil
+// message if the user gives up.
+func aggregateWarpSignature(
 	ctx context.Context,
 	scli *rpc.JSONRPCClient,
-	dcli *rpc.JSONRPCClient,
-	dscli *rpc.WebSocketClient,
-	dtcli *trpc.JSONRPCClient,
 	exportTxID ids.ID,
-	factory chain.AuthFactory,
-) error {
-	// Select TxID (if not provided)
-	var err error
-	if exportTxID == ids.Empty {
-		exportTxID, err = handler.Root().PromptID("export txID")
-		if err != nil {
-			return err
-		}
-	}
-
-	// Generate warp signature (as long as >= 80% stake)
+) (*warp.Message, uint64, uint64, error) {
 	var (
 		msg                     *warp.Message
 		subnetWeight, sigWeight uint64
+		err                     error
 	)
 	for ctx.Err() == nil {
 		msg, subnetWeight, sigWeight, err = scli.GenerateAggregateWarpSignature(ctx, exportTxID)
@@ -531,15 +521,40 @@ func performImport(
 		}
 		cont, err := handler.Root().PromptBool("try again")
 		if err != nil {
-			return err
+			return nil, 0, 0, err
 		}
 		if !cont {
 			hutils.Outf("{{red}}exiting...{{/}}\n")
-			return nil
+			return nil, 0, 0, nil
 		}
 	}
 	if ctx.Err() != nil {
-		return ctx.Err()
+		return nil, 0, 0, ctx.Err()
+	}
+	return msg, subnetWeight, sigWeight, nil
+}
+
+func performImport(
+	ctx context.Context,
+	scli *rpc.JSONRPCClient,
+	dcli *rpc.JSONRPCClient,
+	dscli *rpc.WebSocketClient,
+	dtcli *trpc.JSONRPCClient,
+	exportTxID ids.ID,
+	factory chain.AuthFactory,
+) error {
+	// Select TxID (if not provided)
+	var err error
+	if exportTxID == ids.Empty {
+		exportTxID, err = handler.Root().PromptID("export txID")
+		if err != nil {
+			return err
+		}
+	}
+
+	msg, subnetWeight, sigWeight, err := aggregateWarpSignature(ctx, scli, exportTxID)
+	if msg == nil || err != nil {
+		return err
 	}
 	wt, err := actions.UnmarshalWarpTransfer(msg.UnsignedMessage.Payload)
 	if err != nil {
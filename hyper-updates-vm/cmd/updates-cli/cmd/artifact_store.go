@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	pinataStore     = "pinata"
+	kuboStore       = "kubo"
+	filesystemStore = "filesystem"
+	s3Store         = "s3"
+
+	defaultArtifactStore = pinataStore
+	defaultIPFSGateway   = "https://ipfs.io"
+	defaultKuboAPI       = "http://127.0.0.1:5001"
+	defaultS3Region      = "us-east-1"
+)
+
+// ArtifactStore holds the executables, patches and manifest artifacts of
+// updates. The URL returned by Put is recorded on chain, the other methods
+// take such a URL.
+type ArtifactStore interface {
+	// Put uploads the file at [filePath] and returns its URL.
+	Put(ctx context.Context, filePath string) (string, error)
+
+	// Get downloads the artifact at [url] into [filePath]. It returns
+	// [ErrUnknownArtifactURL] if [url] does not belong to the store.
+	Get(ctx context.Context, url string, filePath string) error
+
+	// Stat returns the size of the artifact at [url] in bytes.
+	Stat(ctx context.Context, url string) (int64, error)
+
+	// Delete removes the artifact at [url], releases still referencing it
+	// can then no longer be installed.
+	Delete(ctx context.Context, url string) error
+}
+
+// ArtifactStoreConfig selects and configures the [ArtifactStore], either from
+// the artifact flags or from the JSON file passed with --artifact-config.
+// Flags take precedence over the file.
+type ArtifactStoreConfig struct {
+	Store string `json:"store"` // pinata, kubo, filesystem or s3
+
+	// IPFS gateway artifacts pinned to Pinata or Kubo are downloaded from
+	Gateway string `json:"gateway"`
+
+	PinataAPIKey       string `json:"pinataApiKey"`
+	PinataSecretAPIKey string `json:"pinataSecretApiKey"`
+
+	KuboAPI string `json:"kuboApi"`
+
+	Dir string `json:"dir"`
+
+	S3Endpoint  string `json:"s3Endpoint"`
+	S3Region    string `json:"s3Region"`
+	S3Bucket    string `json:"s3Bucket"`
+	S3AccessKey string `json:"s3AccessKey"`
+	S3SecretKey string `json:"s3SecretKey"`
+}
+
+// merge overrides the settings of [c] with those set in [o].
+func (c *ArtifactStoreConfig) merge(o *ArtifactStoreConfig) {
+	for _, field := range []struct{ dst, src *string }{
+		{&c.Store, &o.Store},
+		{&c.Gateway, &o.Gateway},
+		{&c.PinataAPIKey, &o.PinataAPIKey},
+		{&c.PinataSecretAPIKey, &o.PinataSecretAPIKey},
+		{&c.KuboAPI, &o.KuboAPI},
+		{&c.Dir, &o.Dir},
+		{&c.S3Endpoint, &o.S3Endpoint},
+		{&c.S3Region, &o.S3Region},
+		{&c.S3Bucket, &o.S3Bucket},
+		{&c.S3AccessKey, &o.S3AccessKey},
+		{&c.S3SecretKey, &o.S3SecretKey},
+	} {
+		if len(*field.src) > 0 {
+			*field.dst = *field.src
+		}
+	}
+}
+
+func (c *ArtifactStoreConfig) setDefault() {
+	if len(c.Store) == 0 {
+		c.Store = defaultArtifactStore
+	}
+	if len(c.Gateway) == 0 {
+		c.Gateway = defaultIPFSGateway
+	}
+	if len(c.KuboAPI) == 0 {
+		c.KuboAPI = defaultKuboAPI
+	}
+	if len(c.S3Region) == 0 {
+		c.S3Region = defaultS3Region
+	}
+}
+
+// NewArtifactStore returns the store selected by [config].
+func NewArtifactStore(config *ArtifactStoreConfig) (ArtifactStore, error) {
+	gateway := strings.TrimSuffix(config.Gateway, "/")
+	switch config.Store {
+	case pinataStore:
+		if len(config.PinataAPIKey) == 0 || len(config.PinataSecretAPIKey) == 0 {
+			return nil, fmt.Errorf("%w: pinata api key and secret", ErrMissingArtifactConfig)
+		}
+		return NewPinataStore(config.PinataAPIKey, config.PinataSecretAPIKey, gateway), nil
+	case kuboStore:
+		return NewKuboStore(strings.TrimSuffix(config.KuboAPI, "/"), gateway), nil
+	case filesystemStore:
+		if len(config.Dir) == 0 {
+			return nil, fmt.Errorf("%w: artifact directory", ErrMissingArtifactConfig)
+		}
+		return NewFilesystemStore(config.Dir)
+	case s3Store:
+		if len(config.S3Endpoint) == 0 || len(config.S3Bucket) == 0 {
+			return nil, fmt.Errorf("%w: s3 endpoint and bucket", ErrMissingArtifactConfig)
+		}
+		return NewS3Store(
+			strings.TrimSuffix(config.S3Endpoint, "/"),
+			config.S3Region,
+			config.S3Bucket,
+			config.S3AccessKey,
+			config.S3SecretKey,
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownArtifactStore, config.Store)
+	}
+}
+
+// loadArtifactStore returns the store configured on the command line.
+func loadArtifactStore() (ArtifactStore, error) {
+	config := &ArtifactStoreConfig{}
+	if len(artifactConfigPath) > 0 {
+		b, err := os.ReadFile(artifactConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, config); err != nil {
+			return nil, err
+		}
+	}
+	config.merge(&artifactFlags)
+	config.setDefault()
+	return NewArtifactStore(config)
+}
+
+// downloadArtifact downloads [url] into [filePath]. URLs the store does not
+// know, like those of releases published before it was changed, are fetched
+// over plain HTTP.
+func downloadArtifact(ctx context.Context, store ArtifactStore, url string, filePath string) error {
+	err := store.Get(ctx, url, filePath)
+	if errors.Is(err, ErrUnknownArtifactURL) && (strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")) {
+		err = httpDownload(ctx, url, filePath)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println("File downloaded successfully:", filePath)
+	return nil
+}
+
+// httpDownload writes the body of the response to [url] into [filePath].
+func httpDownload(ctx context.Context, url string, filePath string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	return writeFile(filePath, resp.Body)
+}
+
+// checkResponse returns an error carrying the body of [resp] if it is not a
+// success.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("%w: status code %d, %s", ErrArtifactRequest, resp.StatusCode, body)
+}
+
+func writeFile(filePath string, r io.Reader) error {
+	out, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		os.Remove(filePath)
+		return err
+	}
+	return out.Close()
+}
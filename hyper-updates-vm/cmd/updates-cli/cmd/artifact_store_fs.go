@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ ArtifactStore = (*FilesystemStore)(nil)
+
+// FilesystemStore keeps artifacts in a local directory, named after their
+// SHA-256 digest. Its file:// URLs only resolve on the machine holding the
+// directory, which lets the publish and push pipeline run offline.
+type FilesystemStore struct {
+	dir string
+}
+
+func NewFilesystemStore(dir string) (*FilesystemStore, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FilesystemStore{dir}, nil
+}
+
+// path returns the file [artifactURL] points to, if it is in the store.
+func (f *FilesystemStore) path(artifactURL string) (string, error) {
+	path, ok := strings.CutPrefix(artifactURL, "file://")
+	if !ok || filepath.Dir(filepath.Clean(path)) != f.dir {
+		return "", ErrUnknownArtifactURL
+	}
+	return filepath.Clean(path), nil
+}
+
+func (f *FilesystemStore) Put(_ context.Context, filePath string) (string, error) {
+	digest, err := fileSHA256(filePath)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(f.dir, digest)
+	if err := copyFile(path, filePath); err != nil {
+		return "", err
+	}
+	return "file://" + path, nil
+}
+
+func (f *FilesystemStore) Get(_ context.Context, artifactURL string, filePath string) error {
+	path, err := f.path(artifactURL)
+	if err != nil {
+		return err
+	}
+	return copyFile(filePath, path)
+}
+
+func (f *FilesystemStore) Stat(_ context.Context, artifactURL string) (int64, error) {
+	path, err := f.path(artifactURL)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+func (f *FilesystemStore) Delete(_ context.Context, artifactURL string) error {
+	path, err := f.path(artifactURL)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// fileSHA256 returns the hex SHA-256 digest of the file at [filePath], used
+// to address artifacts in stores without their own addressing.
+func fileSHA256(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
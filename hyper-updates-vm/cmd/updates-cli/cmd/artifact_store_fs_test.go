@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewFilesystemStore(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("firmware")
+	src := filepath.Join(dir, "firmware.bin")
+	if err := os.WriteFile(src, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	url, err := store.Put(ctx, src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Artifacts are named after their digest, the same file is stored once
+	if again, err := store.Put(ctx, src); err != nil || again != url {
+		t.Fatalf("Put again = %s, %v, want %s", again, err, url)
+	}
+
+	size, err := store.Stat(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != int64(len(content)) {
+		t.Errorf("Stat = %d, want %d", size, len(content))
+	}
+
+	dst := filepath.Join(dir, "download.bin")
+	if err := store.Get(ctx, url, dst); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Errorf("Get = %q, want %q", got, content)
+	}
+
+	if err := store.Delete(ctx, url); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Stat(ctx, url); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Stat after Delete error = %v, want %v", err, os.ErrNotExist)
+	}
+	if err := store.Get(ctx, url, dst); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Get after Delete error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestFilesystemStorePath(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewFilesystemStore(filepath.Join(dir, "store"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(dir, "secret")
+	if err := os.WriteFile(secret, []byte("secret"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	inside := filepath.Join(store.dir, "artifact")
+	if path, err := store.path("file://" + inside); err != nil || path != inside {
+		t.Errorf("path(%s) = %s, %v, want %s", inside, path, err, inside)
+	}
+	for _, url := range []string{
+		"file://" + secret,
+		"file://" + store.dir,
+		"file://" + store.dir + "/../secret",
+		"file://" + store.dir + "/sub/artifact",
+		"file://" + store.dir + "-other/artifact",
+		"file://artifact",
+		store.dir + "/artifact",
+		"https://ipfs.io/ipfs/artifact",
+		"",
+	} {
+		if _, err := store.path(url); !errors.Is(err, ErrUnknownArtifactURL) {
+			t.Errorf("path(%q) error = %v, want %v", url, err, ErrUnknownArtifactURL)
+		}
+	}
+
+	// Outside files are neither read nor removed
+	ctx := context.Background()
+	if err := store.Get(ctx, "file://"+secret, filepath.Join(dir, "leak")); !errors.Is(err, ErrUnknownArtifactURL) {
+		t.Errorf("Get outside the store error = %v, want %v", err, ErrUnknownArtifactURL)
+	}
+	if err := store.Delete(ctx, "file://"+store.dir+"/../secret"); !errors.Is(err, ErrUnknownArtifactURL) {
+		t.Errorf("Delete outside the store error = %v, want %v", err, ErrUnknownArtifactURL)
+	}
+	if _, err := os.Stat(secret); err != nil {
+		t.Errorf("file outside the store was removed: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const pinataAPI = "https://api.pinata.cloud"
+
+var (
+	_ ArtifactStore = (*PinataStore)(nil)
+	_ ArtifactStore = (*KuboStore)(nil)
+)
+
+// parseCID returns the CID of an ipfs://<cid> URL or of a gateway URL
+// <gateway>/ipfs/<cid>, whatever the gateway.
+func parseCID(artifactURL string) (string, error) {
+	var cid string
+	if rest, ok := strings.CutPrefix(artifactURL, "ipfs://"); ok {
+		cid = rest
+	} else if i := strings.Index(artifactURL, "/ipfs/"); i >= 0 {
+		cid = artifactURL[i+len("/ipfs/"):]
+	}
+	if j := strings.IndexAny(cid, "/?#"); j >= 0 {
+		cid = cid[:j]
+	}
+	if len(cid) == 0 {
+		return "", ErrUnknownArtifactURL
+	}
+	return cid, nil
+}
+
+// multipartFile returns a multipart/form-data body holding the file at
+// [filePath] in the "file" field, and its content type.
+func multipartFile(filePath string) (*bytes.Buffer, string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, "", err
+	}
+	defer file.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, "", err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return &body, writer.FormDataContentType(), nil
+}
+
+// gatewayStat returns the size of [cid] as reported by [gateway].
+func gatewayStat(ctx context.Context, gateway, cid string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, gateway+"/ipfs/"+cid, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return 0, err
+	}
+	return resp.ContentLength, nil
+}
+
+// PinataStore pins artifacts with the Pinata pinning service, they are
+// downloaded through an IPFS gateway.
+type PinataStore struct {
+	apiKey       string
+	secretAPIKey string
+	gateway      string
+}
+
+func NewPinataStore(apiKey, secretAPIKey, gateway string) *PinataStore {
+	return &PinataStore{apiKey, secretAPIKey, gateway}
+}
+
+type PinataResponse struct {
+	IpfsHash string `json:"IpfsHash"`
+}
+
+func (p *PinataStore) do(req *http.Request) (*http.Response, error) {
+	req.Header.Add("pinata_api_key", p.apiKey)
+	req.Header.Add("pinata_secret_api_key", p.secretAPIKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (p *PinataStore) Put(ctx context.Context, filePath string) (string, error) {
+	body, contentType, err := multipartFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pinataAPI+"/pinning/pinFileToIPFS", body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := p.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var pinataResponse PinataResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pinataResponse); err != nil {
+		return "", err
+	}
+	return p.gateway + "/ipfs/" + pinataResponse.IpfsHash, nil
+}
+
+func (p *PinataStore) Get(ctx context.Context, artifactURL string, filePath string) error {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return err
+	}
+	return httpDownload(ctx, p.gateway+"/ipfs/"+cid, filePath)
+}
+
+func (p *PinataStore) Stat(ctx context.Context, artifactURL string) (int64, error) {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return 0, err
+	}
+	return gatewayStat(ctx, p.gateway, cid)
+}
+
+func (p *PinataStore) Delete(ctx context.Context, artifactURL string) error {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, pinataAPI+"/pinning/unpin/"+cid, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// KuboStore pins artifacts on an IPFS node through the Kubo RPC API, usually
+// a node run next to the server. Artifacts are read back from the node and
+// published with gateway URLs so devices can fetch them too.
+type KuboStore struct {
+	api     string
+	gateway string
+}
+
+func NewKuboStore(api, gateway string) *KuboStore {
+	return &KuboStore{api, gateway}
+}
+
+type KuboAddResponse struct {
+	Hash string `json:"Hash"`
+}
+
+type KuboStatResponse struct {
+	Size int64 `json:"Size"`
+}
+
+// call sends [body] to the Kubo RPC [method], which only accepts POST.
+func (k *KuboStore) call(
+	ctx context.Context,
+	method string,
+	query url.Values,
+	body io.Reader,
+	contentType string,
+) (*http.Response, error) {
+	endpoint := k.api + "/api/v0/" + method + "?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(contentType) > 0 {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (k *KuboStore) Put(ctx context.Context, filePath string) (string, error) {
+	body, contentType, err := multipartFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	resp, err := k.call(ctx, "add", url.Values{"pin": {"true"}, "cid-version": {"1"}}, body, contentType)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var added KuboAddResponse
+	if err := json.NewDecoder(resp.Body).Decode(&added); err != nil {
+		return "", err
+	}
+	return k.gateway + "/ipfs/" + added.Hash, nil
+}
+
+func (k *KuboStore) Get(ctx context.Context, artifactURL string, filePath string) error {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return err
+	}
+	resp, err := k.call(ctx, "cat", url.Values{"arg": {cid}}, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return writeFile(filePath, resp.Body)
+}
+
+func (k *KuboStore) Stat(ctx context.Context, artifactURL string) (int64, error) {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := k.call(ctx, "files/stat", url.Values{"arg": {"/ipfs/" + cid}}, nil, "")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var stat KuboStatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stat); err != nil {
+		return 0, err
+	}
+	return stat.Size, nil
+}
+
+// Delete unpins the artifact, the node reclaims it on its next garbage
+// collection.
+func (k *KuboStore) Delete(ctx context.Context, artifactURL string) error {
+	cid, err := parseCID(artifactURL)
+	if err != nil {
+		return err
+	}
+	resp, err := k.call(ctx, "pin/rm", url.Values{"arg": {cid}}, nil, "")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// emptySHA256 is the payload hash of requests without a body
+const emptySHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+var _ ArtifactStore = (*S3Store)(nil)
+
+// S3Store keeps artifacts in a bucket of an S3-compatible service (AWS S3,
+// MinIO, R2, ...) addressed path-style, named after their SHA-256 digest.
+// Devices can download them if the bucket allows public reads.
+type S3Store struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+}
+
+func NewS3Store(endpoint, region, bucket, accessKey, secretKey string) *S3Store {
+	return &S3Store{endpoint, region, bucket, accessKey, secretKey}
+}
+
+func (s *S3Store) objectURL(key string) string {
+	return s.endpoint + "/" + s.bucket + "/" + key
+}
+
+// key returns the object [artifactURL] points to, if it is in the bucket.
+func (s *S3Store) key(artifactURL string) (string, error) {
+	key, ok := strings.CutPrefix(artifactURL, s.objectURL(""))
+	if !ok || len(key) == 0 {
+		return "", ErrUnknownArtifactURL
+	}
+	return key, nil
+}
+
+// do signs [req] with AWS Signature Version 4 and sends it.
+func (s *S3Store) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalHash[:])
+
+	key := []byte("AWS4" + s.secretKey)
+	for _, part := range []string{date, s.region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+s.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+hex.EncodeToString(key))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, filePath string) (string, error) {
+	// The digest names the object and signs the payload
+	digest, err := fileSHA256(filePath)
+	if err != nil {
+		return "", err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	url := s.objectURL(digest)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, file)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	resp, err := s.do(req, digest)
+	if err != nil {
+		return "", err
+	}
+	return url, resp.Body.Close()
+}
+
+func (s *S3Store) Get(ctx context.Context, artifactURL string, filePath string) error {
+	key, err := s.key(artifactURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return writeFile(filePath, resp.Body)
+}
+
+func (s *S3Store) Stat(ctx context.Context, artifactURL string) (int64, error) {
+	key, err := s.key(artifactURL)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.objectURL(key), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.ContentLength, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, artifactURL string) error {
+	key, err := s.key(artifactURL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, emptySHA256)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
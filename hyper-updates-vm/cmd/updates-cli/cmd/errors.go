@@ -6,9 +6,21 @@ package cmd
 import "errors"
 
 var (
-	ErrInvalidArgs        = errors.New("invalid args")
-	ErrMissingSubcommand  = errors.New("must specify a subcommand")
-	ErrNotMultiple        = errors.New("must be a multiple")
-	ErrInsufficientSupply = errors.New("insufficient supply")
-	ErrMustFill           = errors.New("must fill")
+	ErrInvalidArgs           = errors.New("invalid args")
+	ErrMissingSubcommand     = errors.New("must specify a subcommand")
+	ErrNotMultiple           = errors.New("must be a multiple")
+	ErrInsufficientSupply    = errors.New("insufficient supply")
+	ErrMustFill              = errors.New("must fill")
+	ErrInvalidChannel        = errors.New("invalid channel")
+	ErrInvalidRollout        = errors.New("rollout percentage must be between 0 and 100")
+	ErrInvalidRevision       = errors.New("hardware revision must be between 0 and 65535")
+	ErrDigestMismatch        = errors.New("file does not match the digest on chain")
+	ErrInvalidLicense        = errors.New("license minimum balance must be positive")
+	ErrMQTTTimeout           = errors.New("timed out waiting for the MQTT broker")
+	ErrInvalidTopic          = errors.New("device name cannot be used as an MQTT topic level")
+	ErrUnknownArtifactStore  = errors.New("unknown artifact store")
+	ErrMissingArtifactConfig = errors.New("artifact store is missing")
+	ErrUnknownArtifactURL    = errors.New("artifact URL does not belong to the store")
+	ErrArtifactRequest       = errors.New("artifact store request failed")
+	ErrManifestApp           = errors.New("the app artifact is the update executable, it can't be listed in the manifest")
 )
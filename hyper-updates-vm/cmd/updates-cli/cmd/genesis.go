@@ -12,6 +12,7 @@ import (
 
 	"hyper-updates/genesis"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/chain"
 )
 
@@ -57,6 +58,13 @@ var genGenesisCmd = &cobra.Command{
 		if minBlockGap >= 0 {
 			g.MinBlockGap = minBlockGap
 		}
+		for _, s := range warpSourceChains {
+			chainID, err := ids.FromString(s)
+			if err != nil {
+				return err
+			}
+			g.WarpSourceChains = append(g.WarpSourceChains, chainID)
+		}
 
 		a, err := os.ReadFile(args[0])
 		if err != nil {
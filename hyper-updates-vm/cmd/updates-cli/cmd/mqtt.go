@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"hyper-updates/actions"
+	trpc "hyper-updates/rpc"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/rpc"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	mqttTopicPrefix = "hyper-updates"
+	mqttTimeout     = 10 * time.Second
+
+	// Devices must receive every release, even the ones published while they
+	// were offline
+	mqttQoS byte = 1
+)
+
+// UpdateNotification is published to the devices an update was released
+// for, they then pull the update through its transaction id.
+type UpdateNotification struct {
+	TxID    string `json:"txid"`
+	Version string `json:"version"`
+	Digest  string `json:"digest"`
+}
+
+// mqttClient is the part of [mqtt.Client] the publisher uses, tests replace
+// it with a fake.
+type mqttClient interface {
+	Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token
+	Disconnect(quiesce uint)
+}
+
+// mqttPublisher notifies devices of new updates on
+// hyper-updates/<project>/<device>. Messages are retained so devices
+// connecting later still receive the latest release.
+type mqttPublisher struct {
+	client mqttClient
+}
+
+// newMQTTPublisher connects to [broker], for example tcp://localhost:1883 or
+// ssl://broker:8883. [username] may be empty for brokers allowing anonymous
+// clients.
+func newMQTTPublisher(broker, clientID, username, password string) (*mqttPublisher, error) {
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(clientID).
+		SetUsername(username).
+		SetPassword(password).
+		SetConnectTimeout(mqttTimeout).
+		SetAutoReconnect(true)
+	client := mqtt.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(mqttTimeout) {
+		return nil, fmt.Errorf("%w: %s", ErrMQTTTimeout, broker)
+	}
+	if err := token.Error(); err != nil {
+		return nil, err
+	}
+	return &mqttPublisher{client}, nil
+}
+
+// mqttTopic returns the topic the releases of [project] for [device] are
+// published on.
+func mqttTopic(project, device string) (string, error) {
+	// Wildcards and separators would publish to other devices
+	if len(device) == 0 || strings.ContainsAny(device, "/+#") {
+		return "", ErrInvalidTopic
+	}
+	return mqttTopicPrefix + "/" + project + "/" + device, nil
+}
+
+func (p *mqttPublisher) Publish(project, device string, notification *UpdateNotification) error {
+	topic, err := mqttTopic(project, device)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	token := p.client.Publish(topic, mqttQoS, true, payload)
+	if !token.WaitTimeout(mqttTimeout) {
+		return fmt.Errorf("%w: %s", ErrMQTTTimeout, topic)
+	}
+	return token.Error()
+}
+
+func (p *mqttPublisher) Close() {
+	// Give in-flight messages a second to be acknowledged
+	p.client.Disconnect(1000)
+}
+
+// notifyUpdate publishes [updateID] to the devices it was released for.
+// Drafts are not released yet and are skipped, devices are notified once
+// the approval releasing them is accepted.
+func notifyUpdate(ctx context.Context, tcli *trpc.JSONRPCClient, publisher *mqttPublisher, updateID ids.ID) error {
+	update, err := tcli.Update(ctx, updateID, false)
+	if err != nil {
+		return err
+	}
+	if update.Draft {
+		return nil
+	}
+	project, err := actions.ParseProjectID(update.ProjectTxID)
+	if err != nil {
+		return err
+	}
+	if err := publisher.Publish(project.String(), string(update.ForDeviceName), &UpdateNotification{
+		TxID:    updateID.String(),
+		Version: update.UpdateVersion,
+		Digest:  update.Digest,
+	}); err != nil {
+		return err
+	}
+	fmt.Println("Notified", string(update.ForDeviceName), "devices of update", updateID)
+	return nil
+}
+
+// releasedUpdate returns the project and update whose latest update pointer
+// [tx] may have moved, if any.
+func releasedUpdate(tx *chain.Transaction) (ids.ID, ids.ID, bool) {
+	switch action := tx.Action.(type) {
+	case *actions.CreateUpdate:
+		project, err := actions.ParseProjectID(action.ProjectTxID)
+		if err != nil {
+			return ids.Empty, ids.Empty, false
+		}
+		return project, tx.ID(), true
+	case *actions.ApproveUpdate:
+		return action.Project, action.Update, true
+	case *actions.PromoteUpdate:
+		return action.Project, action.Update, true
+	case *actions.ImportUpdate:
+		if tx.WarpMessage == nil {
+			return ids.Empty, ids.Empty, false
+		}
+		wu, err := actions.UnmarshalWarpUpdate(tx.WarpMessage.Payload)
+		if err != nil {
+			return ids.Empty, ids.Empty, false
+		}
+		return wu.Project, tx.ID(), true
+	default:
+		return ids.Empty, ids.Empty, false
+	}
+}
+
+// watchProjects notifies devices of the updates released for [projects] by
+// anyone, not only through this server. It returns once the connection to
+// the chain is lost.
+func watchProjects(ctx context.Context, tcli *trpc.JSONRPCClient, scli *rpc.WebSocketClient, publisher *mqttPublisher, projects set.Set[ids.ID]) error {
+	parser, err := tcli.Parser(ctx)
+	if err != nil {
+		return err
+	}
+	if err := scli.RegisterBlocks(); err != nil {
+		return err
+	}
+	for {
+		blk, results, _, err := scli.ListenBlock(ctx, parser)
+		if err != nil {
+			return err
+		}
+		for i, tx := range blk.Txs {
+			if !results[i].Success {
+				continue
+			}
+			project, update, ok := releasedUpdate(tx)
+			if !ok || !projects.Contains(project) {
+				continue
+			}
+			if err := notifyUpdate(ctx, tcli, publisher, update); err != nil {
+				fmt.Println("Cannot notify devices of update", update, ":", err)
+			}
+		}
+	}
+}
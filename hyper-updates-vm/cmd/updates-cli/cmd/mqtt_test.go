@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"hyper-updates/actions"
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+type testToken struct {
+	timeout bool
+	err     error
+}
+
+func (t *testToken) Wait() bool { return !t.timeout }
+
+func (t *testToken) WaitTimeout(time.Duration) bool { return !t.timeout }
+
+func (*testToken) Done() <-chan struct{} {
+	done := make(chan struct{})
+	close(done)
+	return done
+}
+
+func (t *testToken) Error() error { return t.err }
+
+type testMessage struct {
+	topic    string
+	qos      byte
+	retained bool
+	payload  []byte
+}
+
+// testClient records what is published instead of talking to a broker.
+type testClient struct {
+	token        *testToken
+	published    []testMessage
+	disconnected bool
+}
+
+func (c *testClient) Publish(topic string, qos byte, retained bool, payload interface{}) mqtt.Token {
+	c.published = append(c.published, testMessage{topic, qos, retained, payload.([]byte)})
+	return c.token
+}
+
+func (c *testClient) Disconnect(uint) {
+	c.disconnected = true
+}
+
+func TestMQTTPublish(t *testing.T) {
+	client := &testClient{token: &testToken{}}
+	publisher := &mqttPublisher{client}
+	notification := &UpdateNotification{TxID: "txid", Version: "1.2.0", Digest: "sha256:00"}
+	if err := publisher.Publish("project", "esp32-s3", notification); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.published) != 1 {
+		t.Fatalf("published %d messages, want 1", len(client.published))
+	}
+	msg := client.published[0]
+	if msg.topic != "hyper-updates/project/esp32-s3" {
+		t.Errorf("topic = %s", msg.topic)
+	}
+	if msg.qos != mqttQoS || !msg.retained {
+		t.Errorf("qos = %d retained = %t, want %d retained", msg.qos, msg.retained, mqttQoS)
+	}
+	var got UpdateNotification
+	if err := json.Unmarshal(msg.payload, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != *notification {
+		t.Errorf("payload = %+v, want %+v", got, *notification)
+	}
+
+	publisher.Close()
+	if !client.disconnected {
+		t.Error("Close did not disconnect")
+	}
+}
+
+// testBroker starts an in-process broker accepting anonymous clients and
+// returns its address.
+func testBroker(t *testing.T) string {
+	t.Helper()
+	server := mochi.New(&mochi.Options{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		t.Fatal(err)
+	}
+	tcp := listeners.NewTCP("tcp", "127.0.0.1:0", nil)
+	if err := server.AddListener(tcp); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Serve(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	return "tcp://" + tcp.Address()
+}
+
+func TestMQTTPublishBroker(t *testing.T) {
+	broker := testBroker(t)
+	publisher, err := newMQTTPublisher(broker, "updates-server", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer publisher.Close()
+	notification := &UpdateNotification{TxID: "txid", Version: "1.2.0", Digest: "sha256:00"}
+	if err := publisher.Publish("project", "esp32-s3", notification); err != nil {
+		t.Fatal(err)
+	}
+
+	// Devices connecting after the release still receive it
+	received := make(chan mqtt.Message, 1)
+	device := mqtt.NewClient(mqtt.NewClientOptions().AddBroker(broker).SetClientID("esp32-s3"))
+	if token := device.Connect(); !token.WaitTimeout(mqttTimeout) || token.Error() != nil {
+		t.Fatalf("device cannot connect: %v", token.Error())
+	}
+	defer device.Disconnect(0)
+	token := device.Subscribe("hyper-updates/project/esp32-s3", mqttQoS, func(_ mqtt.Client, msg mqtt.Message) {
+		received <- msg
+	})
+	if !token.WaitTimeout(mqttTimeout) || token.Error() != nil {
+		t.Fatalf("device cannot subscribe: %v", token.Error())
+	}
+
+	select {
+	case msg := <-received:
+		if !msg.Retained() {
+			t.Error("notification is not retained")
+		}
+		var got UpdateNotification
+		if err := json.Unmarshal(msg.Payload(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != *notification {
+			t.Errorf("payload = %+v, want %+v", got, *notification)
+		}
+	case <-time.After(mqttTimeout):
+		t.Fatal("device received no notification")
+	}
+}
+
+func TestMQTTPublishInvalidDevice(t *testing.T) {
+	client := &testClient{token: &testToken{}}
+	publisher := &mqttPublisher{client}
+	for _, device := range []string{"", "esp32/s3", "+", "#"} {
+		if err := publisher.Publish("project", device, &UpdateNotification{}); !errors.Is(err, ErrInvalidTopic) {
+			t.Errorf("Publish(%q) error = %v, want %v", device, err, ErrInvalidTopic)
+		}
+	}
+	if len(client.published) != 0 {
+		t.Errorf("published %d messages to invalid topics", len(client.published))
+	}
+}
+
+func TestMQTTPublishFails(t *testing.T) {
+	errBroker := errors.New("not authorized")
+	tests := []struct {
+		token *testToken
+		err   error
+	}{
+		{token: &testToken{timeout: true}, err: ErrMQTTTimeout},
+		{token: &testToken{err: errBroker}, err: errBroker},
+	}
+	for _, tt := range tests {
+		publisher := &mqttPublisher{&testClient{token: tt.token}}
+		if err := publisher.Publish("project", "esp32", &UpdateNotification{}); !errors.Is(err, tt.err) {
+			t.Errorf("Publish error = %v, want %v", err, tt.err)
+		}
+	}
+}
+
+func TestReleasedUpdate(t *testing.T) {
+	project := ids.GenerateTestID()
+	update := ids.GenerateTestID()
+
+	wu := &actions.WarpUpdate{
+		Exporter:             codec.CreateAddress(0, ids.GenerateTestID()),
+		Project:              project,
+		UpdateExecutableHash: []byte("hash"),
+		UpdateIPFSUrl:        []byte("url"),
+		ForDeviceName:        []byte("esp32"),
+		UpdateVersion:        []byte("1.0.0"),
+		Origin: storage.UpdateOrigin{
+			SourceChainID: ids.GenerateTestID(),
+			SourceTxID:    ids.GenerateTestID(),
+			SourceProject: ids.GenerateTestID(),
+		},
+		TxID:               ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+	}
+	payload, err := wu.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported := &warp.Message{UnsignedMessage: warp.UnsignedMessage{Payload: payload}}
+
+	tests := []struct {
+		name    string
+		tx      *chain.Transaction
+		project ids.ID
+		update  ids.ID
+		ok      bool
+	}{
+		{
+			name:    "create",
+			tx:      &chain.Transaction{Action: &actions.CreateUpdate{ProjectTxID: []byte(project.String())}},
+			project: project,
+			ok:      true,
+		},
+		{
+			name:    "approve",
+			tx:      &chain.Transaction{Action: &actions.ApproveUpdate{Project: project, Update: update}},
+			project: project,
+			update:  update,
+			ok:      true,
+		},
+		{
+			name:    "promote",
+			tx:      &chain.Transaction{Action: &actions.PromoteUpdate{Project: project, Update: update}},
+			project: project,
+			update:  update,
+			ok:      true,
+		},
+		{
+			name:    "import",
+			tx:      &chain.Transaction{Action: &actions.ImportUpdate{}, WarpMessage: imported},
+			project: project,
+			ok:      true,
+		},
+		{name: "create without project", tx: &chain.Transaction{Action: &actions.CreateUpdate{}}},
+		{name: "import without message", tx: &chain.Transaction{Action: &actions.ImportUpdate{}}},
+		{name: "revoke", tx: &chain.Transaction{Action: &actions.RevokeUpdate{Project: project, Update: update}}},
+	}
+	for _, tt := range tests {
+		gotProject, gotUpdate, ok := releasedUpdate(tt.tx)
+		if ok != tt.ok {
+			t.Errorf("%s: ok = %t, want %t", tt.name, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		// Created and imported updates are the transaction itself
+		wantUpdate := tt.update
+		if wantUpdate == ids.Empty {
+			wantUpdate = tt.tx.ID()
+		}
+		if gotProject != tt.project || gotUpdate != wantUpdate {
+			t.Errorf("%s: releasedUpdate = %s, %s, want %s, %s", tt.name, gotProject, gotUpdate, tt.project, wantUpdate)
+		}
+	}
+}
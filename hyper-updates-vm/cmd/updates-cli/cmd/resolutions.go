@@ -11,6 +11,7 @@ import (
 	"hyper-updates/actions"
 	tconsts "hyper-updates/consts"
 	trpc "hyper-updates/rpc"
+	"hyper-updates/storage"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
@@ -182,6 +183,53 @@ func handleTx(c *trpc.JSONRPCClient, tx *chain.Transaction, result *chain.Result
 			fmt.Sprintf("Update added with Update Id: %s for Project: %s", tx.ID(), action.ProjectTxID)
 			utils.Outf(summaryStr)
 
+		case *actions.AddMaintainer:
+			summaryStr = fmt.Sprintf("project: %s maintainer: %s roles: %d", action.Project, codec.MustAddressBech32(tconsts.HRP, action.Maintainer), action.Roles)
+		case *actions.RemoveMaintainer:
+			summaryStr = fmt.Sprintf("project: %s maintainer: %s", action.Project, codec.MustAddressBech32(tconsts.HRP, action.Maintainer))
+		case *actions.RevokeUpdate:
+			summaryStr = fmt.Sprintf("update: %s reason: %s", action.Update, formatRevokeReason(action.Reason))
+		case *actions.ReportUpdateResult:
+			summaryStr = fmt.Sprintf("update: %s device: %s status: %s error code: %d", action.Update, action.Device, formatResultStatus(action.Status), action.ErrorCode)
+		case *actions.RegisterDevice:
+			summaryStr = fmt.Sprintf("project: %s device: %s model: %s version: %s", action.Project, storage.DeviceID(action.PublicKey), string(action.Model), string(action.Version))
+		case *actions.UpdateDeviceStatus:
+			summaryStr = fmt.Sprintf("device: %s status: %s", action.Device, formatDeviceStatus(action.Status))
+		case *actions.DecommissionDevice:
+			summaryStr = fmt.Sprintf("device: %s", action.Device)
+		case *actions.DeviceHeartbeat:
+			summaryStr = fmt.Sprintf("device: %s version: %s uptime: %ds", action.Device, string(action.Version), action.Uptime)
+		case *actions.PromoteUpdate:
+			summaryStr = fmt.Sprintf("update: %s channel: %s", action.Update, formatChannel(action.Channel))
+		case *actions.SetRolloutPercentage:
+			summaryStr = fmt.Sprintf("update: %s rollout: %d%%", action.Update, action.Percentage)
+		case *actions.ProposeProjectOwner:
+			summaryStr = fmt.Sprintf("project: %s new owner: %s", action.Project, codec.MustAddressBech32(tconsts.HRP, action.NewOwner))
+		case *actions.AcceptProjectOwner:
+			summaryStr = fmt.Sprintf("project: %s", action.Project)
+		case *actions.EditProject:
+			summaryStr = fmt.Sprintf("project: %s description: %s logo: %s", action.Project, action.ProjectDescription, action.Logo)
+		case *actions.AddSigningKey:
+			summaryStr = fmt.Sprintf("project: %s signing key: %x", action.Project, action.PublicKey[:])
+		case *actions.RemoveSigningKey:
+			summaryStr = fmt.Sprintf("project: %s signing key: %x", action.Project, action.PublicKey[:])
+		case *actions.SetApprovalThreshold:
+			summaryStr = fmt.Sprintf("project: %s approval threshold: %d", action.Project, action.Threshold)
+		case *actions.ApproveUpdate:
+			summaryStr = fmt.Sprintf("update: %s", action.Update)
+		case *actions.SetLicenseAsset:
+			summaryStr = fmt.Sprintf("project: %s license asset: %s", action.Project, action.Asset)
+		case *actions.ExportUpdate:
+			summaryStr = fmt.Sprintf("destination: %s | update: %s -> project: %s", action.Destination, action.Update, action.DestinationProject)
+		case *actions.AddImportSource:
+			summaryStr = fmt.Sprintf("project: %s import source: %s/%s", action.Project, action.SourceChainID, action.SourceProject)
+		case *actions.RemoveImportSource:
+			summaryStr = fmt.Sprintf("project: %s import source: %s/%s", action.Project, action.SourceChainID, action.SourceProject)
+		case *actions.ImportUpdate:
+			wm := tx.WarpMessage
+			signers, _ := wm.Signature.NumSigners()
+			wu, _ := actions.UnmarshalWarpUpdate(wm.Payload)
+			summaryStr = fmt.Sprintf("source: %s signers: %d | project: %s version: %s (origin: %s/%s)", wm.SourceChainID, signers, wu.Project, wu.UpdateVersion, wu.Origin.SourceChainID, wu.Origin.SourceTxID)
 		}
 	}
 	utils.Outf(
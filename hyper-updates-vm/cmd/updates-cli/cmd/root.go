@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"time"
 
+	"hyper-updates/storage"
+
 	"github.com/ava-labs/hypersdk/cli"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
@@ -24,6 +26,7 @@ var (
 	dbPath                string
 	genesisFile           string
 	minBlockGap           int64
+	warpSourceChains      []string
 	minUnitPrice          []string
 	maxBlockUnits         []string
 	windowTargetUnits     []string
@@ -38,6 +41,30 @@ var (
 	startPrometheus       bool
 	maxFee                int64
 	numCores              int
+	releaseChannel        string
+	rolloutPercentage     int
+	digestAlgorithm       string
+	updateSignature       string
+	baseUpdate            string
+	manifestPath          string
+	updateBoards          []string
+	minHardwareRevision   int
+	minSourceVersion      string
+	maxSourceVersion      string
+	passThrough           string
+	notBefore             string
+	expiresAt             string
+	licenseAsset          string
+	licenseMinBalance     uint64
+	licenseAmount         uint64
+	staleMinutes          int
+	mqttBroker            string
+	mqttClientID          string
+	mqttUsername          string
+	mqttPassword          string
+	mqttWatch             []string
+	artifactConfigPath    string
+	artifactFlags         ArtifactStoreConfig
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -64,6 +91,80 @@ func init() {
 		defaultDatabase,
 		"path to database (will create it missing)",
 	)
+
+	// artifacts
+	rootCmd.PersistentFlags().StringVar(
+		&artifactConfigPath,
+		"artifact-config",
+		"",
+		"JSON file configuring the artifact store, overridden by the artifact flags",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.Store,
+		"artifact-store",
+		"",
+		"store update artifacts are uploaded to: pinata, kubo, filesystem or s3 (default pinata)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.Gateway,
+		"ipfs-gateway",
+		"",
+		"IPFS gateway artifacts are downloaded from (default https://ipfs.io)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.PinataAPIKey,
+		"pinata-api-key",
+		"",
+		"Pinata API key",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.PinataSecretAPIKey,
+		"pinata-secret-api-key",
+		"",
+		"Pinata secret API key",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.KuboAPI,
+		"kubo-api",
+		"",
+		"Kubo RPC API of the IPFS node (default http://127.0.0.1:5001)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.Dir,
+		"artifact-dir",
+		"",
+		"directory artifacts are kept in by the filesystem store",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.S3Endpoint,
+		"s3-endpoint",
+		"",
+		"S3-compatible endpoint, e.g. https://s3.us-east-1.amazonaws.com",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.S3Region,
+		"s3-region",
+		"",
+		"S3 region (default us-east-1)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.S3Bucket,
+		"s3-bucket",
+		"",
+		"S3 bucket",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.S3AccessKey,
+		"s3-access-key",
+		"",
+		"S3 access key",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&artifactFlags.S3SecretKey,
+		"s3-secret-key",
+		"",
+		"S3 secret key",
+	)
 	rootCmd.PersistentPreRunE = func(*cobra.Command, []string) error {
 		utils.Outf("{{yellow}}database:{{/}} %s\n", dbPath)
 		controller := NewController(dbPath)
@@ -110,6 +211,12 @@ func init() {
 		-1,
 		"minimum block gap (ms)",
 	)
+	genGenesisCmd.PersistentFlags().StringSliceVar(
+		&warpSourceChains,
+		"warp-source-chains",
+		[]string{},
+		"chains allowed to send warp messages",
+	)
 	genesisCmd.AddCommand(
 		genGenesisCmd,
 	)
@@ -170,14 +277,197 @@ func init() {
 	)
 
 	// deploy
+	createUpdateCmd.PersistentFlags().StringVar(
+		&releaseChannel,
+		"channel",
+		"stable",
+		"release channel (stable, beta, canary)",
+	)
+	createUpdateCmd.PersistentFlags().IntVar(
+		&rolloutPercentage,
+		"rollout",
+		int(storage.MaxRolloutPercentage),
+		"percentage of the fleet the update is offered to",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&digestAlgorithm,
+		"digest",
+		storage.DefaultDigestAlgorithm.String(),
+		"executable digest algorithm (sha256, sha512, blake3)",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&updateSignature,
+		"signature",
+		"",
+		"vendor signature from deploy sign (hex)",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&baseUpdate,
+		"base",
+		"",
+		"update to also publish a binary patch against",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&manifestPath,
+		"manifest",
+		"",
+		"manifest file listing artifacts shipped with the executable",
+	)
+	createUpdateCmd.PersistentFlags().StringSliceVar(
+		&updateBoards,
+		"board",
+		nil,
+		"device model the update runs on, may be repeated (any if omitted)",
+	)
+	createUpdateCmd.PersistentFlags().IntVar(
+		&minHardwareRevision,
+		"min-hardware-revision",
+		0,
+		"lowest hardware revision the update runs on",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&minSourceVersion,
+		"min-source-version",
+		"",
+		"lowest version devices can update from",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&maxSourceVersion,
+		"max-source-version",
+		"",
+		"highest version devices can update from",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&passThrough,
+		"pass-through",
+		"",
+		"update devices on older versions must install first",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&notBefore,
+		"not-before",
+		"",
+		"time the update becomes available (RFC 3339)",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&expiresAt,
+		"expires-at",
+		"",
+		"time the update stops being offered (RFC 3339)",
+	)
+	createUpdateCmd.PersistentFlags().StringVar(
+		&licenseAsset,
+		"license-asset",
+		"",
+		"license asset devices must hold to install the update",
+	)
+	createUpdateCmd.PersistentFlags().Uint64Var(
+		&licenseMinBalance,
+		"license-min-balance",
+		1,
+		"license balance devices must hold to install the update",
+	)
+	mintLicensesCmd.PersistentFlags().Uint64Var(
+		&licenseAmount,
+		"amount",
+		1,
+		"licenses minted to each device",
+	)
+	fleetStatusCmd.PersistentFlags().IntVar(
+		&staleMinutes,
+		"stale-minutes",
+		15,
+		"minutes without a heartbeat after which a device is reported",
+	)
+	signUpdateCmd.PersistentFlags().StringVar(
+		&digestAlgorithm,
+		"digest",
+		storage.DefaultDigestAlgorithm.String(),
+		"executable digest algorithm (sha256, sha512, blake3)",
+	)
+	signUpdateCmd.PersistentFlags().StringVar(
+		&manifestPath,
+		"manifest",
+		"",
+		"manifest file listing artifacts shipped with the executable",
+	)
 	deployCmd.AddCommand(
 		createRepoCmd,
 		getRepoCmd,
+		editRepoCmd,
+		getRepoHistoryCmd,
+		listRepositoriesCmd,
 		createUpdateCmd,
 		getUpdateCmd,
+		getLatestUpdateCmd,
+		nextUpdateCmd,
+		listUpdatesCmd,
+		addMaintainerCmd,
+		removeMaintainerCmd,
+		getMaintainersCmd,
+		revokeUpdateCmd,
+		reportResultCmd,
+		registerDeviceCmd,
+		updateDeviceStatusCmd,
+		decommissionDeviceCmd,
+		getDeviceCmd,
+		getProjectDevicesCmd,
+		heartbeatCmd,
+		fleetStatusCmd,
+		promoteUpdateCmd,
+		setApprovalThresholdCmd,
+		approveUpdateCmd,
+		getApprovalsCmd,
+		setRolloutPercentageCmd,
+		checkRolloutCmd,
+		proposeProjectOwnerCmd,
+		acceptProjectOwnerCmd,
+		generateSigningKeyCmd,
+		addSigningKeyCmd,
+		removeSigningKeyCmd,
+		getSigningKeysCmd,
+		signUpdateCmd,
+		verifyUpdateSignatureCmd,
+		setLicenseAssetCmd,
+		mintLicensesCmd,
+		exportUpdateCmd,
+		importUpdateCmd,
+		addImportSourceCmd,
+		removeImportSourceCmd,
+		getImportSourcesCmd,
 	)
 
 	// server
+	startServer.PersistentFlags().StringVar(
+		&mqttBroker,
+		"mqtt-broker",
+		"",
+		"MQTT broker devices are notified of new updates through, e.g. tcp://localhost:1883 (disabled if empty)",
+	)
+	startServer.PersistentFlags().StringVar(
+		&mqttClientID,
+		"mqtt-client-id",
+		"updates-server",
+		"MQTT client id",
+	)
+	startServer.PersistentFlags().StringVar(
+		&mqttUsername,
+		"mqtt-username",
+		"",
+		"MQTT username",
+	)
+	startServer.PersistentFlags().StringVar(
+		&mqttPassword,
+		"mqtt-password",
+		"",
+		"MQTT password",
+	)
+	startServer.PersistentFlags().StringSliceVar(
+		&mqttWatch,
+		"mqtt-watch",
+		nil,
+		"project whose accepted updates are notified, may be repeated",
+	)
 	serverCmd.AddCommand(
 		startServer,
 	)
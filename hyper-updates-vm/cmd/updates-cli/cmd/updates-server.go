@@ -3,18 +3,24 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hyper-updates/actions"
+	"hyper-updates/consts"
+	trpc "hyper-updates/rpc"
+	"hyper-updates/storage"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"strconv"
-	"strings"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/codec"
 	"github.com/spf13/cobra"
 )
 
@@ -25,14 +31,6 @@ var serverCmd = &cobra.Command{
 	},
 }
 
-func trimNullChars(s string) string {
-
-	t := strings.TrimRight(s, "\x00")
-	u := strings.TrimLeft(t, "\x00")
-
-	return u
-}
-
 func GetUpdateDataHandler(ctx context.Context) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -55,21 +53,29 @@ func GetUpdateDataHandler(ctx context.Context) http.HandlerFunc {
 
 		} else {
 
-			_, ProjectTxID, UpdateExecutableHash, UpdateIPFSUrl, ForDeviceName, UpdateVersion, _, err := tcli.Update(ctx, transactionId, false)
+			update, err := tcli.Update(ctx, transactionId, false)
 
 			if err != nil {
 				fmt.Fprintln(w, "Server Error")
 			}
 
 			response := map[string]interface{}{
-				"ProjectTxID":          trimNullChars(string(ProjectTxID)),
-				"UpdateExecutableHash": trimNullChars(string(UpdateExecutableHash)),
-				"UpdateIPFSUrl":        trimNullChars(string(UpdateIPFSUrl)),
-				"ForDeviceName":        trimNullChars(string(ForDeviceName)),
-				"UpdateVersion":        UpdateVersion,
+				"ProjectTxID":          string(update.ProjectTxID),
+				"UpdateExecutableHash": update.Digest,
+				"DigestWeak":           update.DigestWeak,
+				"UpdateIPFSUrl":        string(update.UpdateIPFSUrl),
+				"ForDeviceName":        string(update.ForDeviceName),
+				"UpdateVersion":        update.UpdateVersion,
+				"Revoked":              update.Revoked,
+				"RevokeReason":         update.RevokeReason,
+				"RevokeNote":           update.RevokeNote,
+				"NotBefore":            update.NotBefore,
+				"ExpiresAt":            update.ExpiresAt,
+				"Available":            update.Available,
+				"Draft":                update.Draft,
 				"status":               "success",
 			}
-			fmt.Println("Project Tx Id: ", string(ProjectTxID), ", Exe Hash: ", string(UpdateExecutableHash), ", Ipfs URL: ", string(UpdateIPFSUrl), ", For Devide: ", string(ForDeviceName), ", Version: ", UpdateVersion)
+			fmt.Println("Project Tx Id: ", string(update.ProjectTxID), ", Exe Hash: ", update.Digest, ", Ipfs URL: ", string(update.UpdateIPFSUrl), ", For Devide: ", string(update.ForDeviceName), ", Version: ", update.UpdateVersion)
 			w.Header().Set("Content-Type", "application/json")
 
 			// w.WriteHeader(http.StatusOK)
@@ -98,7 +104,7 @@ func GetUpdateHash(ctx context.Context) http.HandlerFunc {
 
 		} else {
 
-			_, ProjectTxID, UpdateExecutableHash, UpdateIPFSUrl, ForDeviceName, UpdateVersion, _, err := tcli.Update(ctx, transactionId, false)
+			update, err := tcli.Update(ctx, transactionId, false)
 
 			if err != nil {
 				fmt.Fprintln(w, "Server Error")
@@ -106,16 +112,35 @@ func GetUpdateHash(ctx context.Context) http.HandlerFunc {
 				return
 			}
 
-			trueHash := trimNullChars(string(UpdateExecutableHash))
+			// A yanked binary must never validate, even if the hash matches
+			if update.Revoked {
+				http.Error(w, "Update has been revoked", http.StatusGone)
+				return
+			}
+			if !checkAvailable(w, update) {
+				return
+			}
+
+			// The device reports the hash in the algorithm of the release,
+			// untagged hashes are legacy MD5 digests
+			trueHash, err := storage.ParseDigestString(update.Digest)
+			if err != nil {
+				http.Error(w, "Update has no valid digest", http.StatusInternalServerError)
+				return
+			}
+			deviceHash, err := storage.ParseDigestString(hash)
 			response := ""
-			if hash != trueHash {
+			if err != nil || !deviceHash.Equal(trueHash) {
 				http.Error(w, "Invalid String", http.StatusBadRequest)
 				return
 			} else {
 				response = "VALID"
 			}
+			if trueHash.Weak() {
+				fmt.Println("Warning: update", transactionId, "is verified with a legacy MD5 digest")
+			}
 
-			fmt.Println("Project Tx Id: ", string(ProjectTxID), ", Exe Hash: ", string(UpdateExecutableHash), ", Ipfs URL: ", string(UpdateIPFSUrl), ", For Devide: ", string(ForDeviceName), ", Version: ", UpdateVersion)
+			fmt.Println("Project Tx Id: ", string(update.ProjectTxID), ", Exe Hash: ", update.Digest, ", Ipfs URL: ", string(update.UpdateIPFSUrl), ", For Devide: ", string(update.ForDeviceName), ", Version: ", update.UpdateVersion)
 			w.Header().Set("Content-Type", "application/json")
 
 			// w.WriteHeader(http.StatusOK)
@@ -126,6 +151,170 @@ func GetUpdateHash(ctx context.Context) http.HandlerFunc {
 
 }
 
+func GetLatestUpdateHandler(ctx context.Context) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		_, _, _, _, _, tcli, _ := handler.DefaultActor()
+
+		projectId, err := ids.FromString(r.URL.Query().Get("project"))
+		if err != nil {
+			http.Error(w, "Invalid Project Id", http.StatusBadRequest)
+			return
+		}
+		device := r.URL.Query().Get("device")
+		if len(device) == 0 {
+			http.Error(w, "Device Name not provided", http.StatusBadRequest)
+			return
+		}
+		channel := actions.ChannelStable
+		if name := r.URL.Query().Get("channel"); len(name) > 0 {
+			channel, err = parseChannel(name)
+			if err != nil {
+				http.Error(w, "Invalid Channel", http.StatusBadRequest)
+				return
+			}
+		}
+
+		updateId, update, err := tcli.LatestUpdate(ctx, projectId, device, channel)
+		if errors.Is(err, trpc.ErrLatestUpdateRevoked) {
+			http.Error(w, "Every release for device has been revoked", http.StatusGone)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Cannot query chain", http.StatusInternalServerError)
+			return
+		}
+		if updateId == ids.Empty {
+			http.Error(w, "No update released for device", http.StatusNotFound)
+			return
+		}
+		if !checkAvailable(w, update) {
+			return
+		}
+		if !checkRollout(ctx, tcli, w, updateId, r.URL.Query().Get("device_id"), update.RolloutPercentage) {
+			return
+		}
+		if !checkLicense(ctx, tcli, w, update, r.URL.Query().Get("address")) {
+			return
+		}
+
+		response := map[string]interface{}{
+			"UpdateTxID":           updateId.String(),
+			"ProjectTxID":          string(update.ProjectTxID),
+			"UpdateExecutableHash": update.Digest,
+			"DigestWeak":           update.DigestWeak,
+			"UpdateIPFSUrl":        string(update.UpdateIPFSUrl),
+			"ForDeviceName":        string(update.ForDeviceName),
+			"UpdateVersion":        update.UpdateVersion,
+			"Channel":              formatChannel(update.Channel),
+			"status":               "success",
+		}
+		// Devices running the base release only need to download the patch
+		if patchApplies(ctx, tcli, update, r.URL.Query().Get("version")) {
+			response["BaseUpdateTxID"] = update.BaseUpdate
+			response["PatchHash"] = update.PatchDigest
+			response["PatchIPFSUrl"] = string(update.PatchIPFSUrl)
+		}
+		if len(update.Manifest) > 0 {
+			response["Manifest"] = update.Manifest
+			response["ManifestHash"] = update.ManifestDigest
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+
+}
+
+// NextUpdateHandler returns the update a device should install next, walking
+// the upgrade path through pass-through releases.
+func NextUpdateHandler(ctx context.Context) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		_, _, _, _, _, tcli, _ := handler.DefaultActor()
+
+		query := r.URL.Query()
+		projectId, err := ids.FromString(query.Get("project"))
+		if err != nil {
+			http.Error(w, "Invalid Project Id", http.StatusBadRequest)
+			return
+		}
+		device := query.Get("device")
+		if len(device) == 0 {
+			http.Error(w, "Device Name not provided", http.StatusBadRequest)
+			return
+		}
+		channel := actions.ChannelStable
+		if name := query.Get("channel"); len(name) > 0 {
+			channel, err = parseChannel(name)
+			if err != nil {
+				http.Error(w, "Invalid Channel", http.StatusBadRequest)
+				return
+			}
+		}
+		version := query.Get("version")
+		if _, err := storage.ParseSemVer(version); err != nil {
+			http.Error(w, "Invalid Version", http.StatusBadRequest)
+			return
+		}
+		revision, err := parseHardwareRevision(query.Get("hardware_revision"))
+		if err != nil {
+			http.Error(w, "Invalid Hardware Revision", http.StatusBadRequest)
+			return
+		}
+
+		// The chain only returns licensed updates to devices holding the
+		// license
+		next, err := tcli.NextUpdate(ctx, projectId, device, channel, query.Get("model"), revision, version, query.Get("address"))
+		if err != nil {
+			http.Error(w, "Cannot query chain", http.StatusInternalServerError)
+			return
+		}
+		if next.UpdateID == ids.Empty {
+			http.Error(w, "No update applies: "+next.Reason, http.StatusNotFound)
+			return
+		}
+		if !checkRollout(ctx, tcli, w, next.UpdateID, query.Get("device_id"), next.Update.RolloutPercentage) {
+			return
+		}
+
+		response := map[string]interface{}{
+			"UpdateTxID":           next.UpdateID.String(),
+			"ProjectTxID":          string(next.Update.ProjectTxID),
+			"UpdateExecutableHash": next.Update.Digest,
+			"DigestWeak":           next.Update.DigestWeak,
+			"UpdateIPFSUrl":        string(next.Update.UpdateIPFSUrl),
+			"ForDeviceName":        string(next.Update.ForDeviceName),
+			"UpdateVersion":        next.Update.UpdateVersion,
+			"Channel":              formatChannel(next.Update.Channel),
+			"status":               "success",
+		}
+		if patchApplies(ctx, tcli, next.Update, version) {
+			response["BaseUpdateTxID"] = next.Update.BaseUpdate
+			response["PatchHash"] = next.Update.PatchDigest
+			response["PatchIPFSUrl"] = string(next.Update.PatchIPFSUrl)
+		}
+		if len(next.Update.Manifest) > 0 {
+			response["Manifest"] = next.Update.Manifest
+			response["ManifestHash"] = next.Update.ManifestDigest
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}
+
+}
+
+// parseHardwareRevision reads an optional hardware revision, devices that
+// don't report one are revision 0.
+func parseHardwareRevision(value string) (uint16, error) {
+	if len(value) == 0 {
+		return 0, nil
+	}
+	revision, err := strconv.ParseUint(value, 10, 16)
+	return uint16(revision), err
+}
+
 type ProjectInfo struct {
 	ProjectName        string `json:"project_name"`
 	ProjectDescription string `json:"project_description"`
@@ -186,7 +375,10 @@ func cleanupFile(file *os.File) {
 	fmt.Println("File deleted successfully.")
 }
 
-func CreateUpdateHandler(ctx context.Context) http.HandlerFunc {
+// CreateUpdateHandler publishes an update and, if [publisher] is set,
+// notifies its devices. Updates of [watched] projects are notified by the
+// project watcher once accepted instead.
+func CreateUpdateHandler(ctx context.Context, store ArtifactStore, publisher *mqttPublisher, watched set.Set[ids.ID]) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -201,7 +393,47 @@ func CreateUpdateHandler(ctx context.Context) http.HandlerFunc {
 		// Extract form values
 		projectID := r.FormValue("project_id")
 		forDeviceName := r.FormValue("for_device_name")
-		version, _ := strconv.ParseUint(r.FormValue("version"), 10, 8)
+		version := r.FormValue("version")
+		if _, err := storage.ParseSemVer(version); err != nil {
+			http.Error(w, "Invalid Version", http.StatusBadRequest)
+			return
+		}
+		channel := actions.ChannelStable
+		if name := r.FormValue("channel"); len(name) > 0 {
+			channel, err = parseChannel(name)
+			if err != nil {
+				http.Error(w, "Invalid Channel", http.StatusBadRequest)
+				return
+			}
+		}
+		rollout := storage.MaxRolloutPercentage
+		if value := r.FormValue("rollout_percentage"); len(value) > 0 {
+			percentage, err := strconv.ParseUint(value, 10, 8)
+			if err != nil || percentage > uint64(storage.MaxRolloutPercentage) {
+				http.Error(w, "Invalid Rollout Percentage", http.StatusBadRequest)
+				return
+			}
+			rollout = uint8(percentage)
+		}
+		algorithm := storage.DefaultDigestAlgorithm
+		if name := r.FormValue("digest_algorithm"); len(name) > 0 {
+			algorithm, err = storage.ParseDigestAlgorithm(name)
+			if err != nil {
+				http.Error(w, "Invalid Digest Algorithm", http.StatusBadRequest)
+				return
+			}
+		}
+		// Made offline with deploy sign, the server never holds signing keys
+		signature, err := hex.DecodeString(r.FormValue("signature"))
+		if err != nil {
+			http.Error(w, "Invalid Signature", http.StatusBadRequest)
+			return
+		}
+		window, err := parseUpdateWindow(r.FormValue("not_before"), r.FormValue("expires_at"))
+		if err != nil {
+			http.Error(w, "Invalid Validity Window", http.StatusBadRequest)
+			return
+		}
 
 		// Get a reference to the uploaded file
 		file, fileHeader, err := r.FormFile("executable_file")
@@ -226,16 +458,17 @@ func CreateUpdateHandler(ctx context.Context) http.HandlerFunc {
 			return
 		}
 
-		executable_ipfs_url, err := DeployBin(
-			fileHeader.Filename,
-			"fc43a725fd778580045c",
-			"37c52b3571d7df2c1326c1460a1b192c209a1fb212c6b1b96eb2626bb2076efe",
-		)
+		executable_ipfs_url, err := store.Put(ctx, fileHeader.Filename)
 
-		executable_hash, err := CalculateMD5(fileHeader.Filename)
+		if err != nil {
+			http.Error(w, "Cannot upload file to artifact store", http.StatusInternalServerError)
+			return
+		}
 
+		executable_hash, err := CalculateDigest(fileHeader.Filename, algorithm)
 		if err != nil {
-			http.Error(w, "Cannot upload file to IPFS", http.StatusInternalServerError)
+			http.Error(w, "Cannot hash file", http.StatusInternalServerError)
+			return
 		}
 		// Print received data
 		fmt.Printf("Received data:\nProject ID: %s\nDevice Name: %s\nVersion: %s\n",
@@ -246,15 +479,27 @@ func CreateUpdateHandler(ctx context.Context) http.HandlerFunc {
 
 		update := &actions.CreateUpdate{
 			ProjectTxID:          []byte(projectID),
-			UpdateExecutableHash: []byte(executable_hash),
+			UpdateExecutableHash: executable_hash.Bytes(),
 			UpdateIPFSUrl:        []byte(executable_ipfs_url),
 			ForDeviceName:        []byte(forDeviceName),
-			UpdateVersion:        uint8(version),
-			SuccessCount:         0,
+			UpdateVersion:        []byte(version),
+			Channel:              channel,
+			RolloutPercentage:    rollout,
+			Signature:            signature,
+			NotBefore:            window.NotBefore,
+			ExpiresAt:            window.ExpiresAt,
 		}
 
 		// Generate transaction
-		_, id, err := sendAndWait(ctx, nil, update, cli, scli, tcli, factory, true)
+		success, id, err := sendAndWait(ctx, nil, update, cli, scli, tcli, factory, true)
+
+		if err == nil && success && publisher != nil {
+			if project, err := ids.FromString(projectID); err == nil && !watched.Contains(project) {
+				if err := notifyUpdate(ctx, tcli, publisher, id); err != nil {
+					fmt.Println("Cannot notify devices of update", id, ":", err)
+				}
+			}
+		}
 
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("File uploaded successfully: " + id.String()))
@@ -266,11 +511,212 @@ func CreateUpdateHandler(ctx context.Context) http.HandlerFunc {
 type PushUpdateInfo struct {
 	UpdateTx string `json:"update-tx"`
 	DeviceIp string `json:"device-ip"`
+	DeviceID string `json:"device-id"` // required while the update is rolling out
+
+	// DeviceVersion is the version the device runs, devices on the base
+	// release of a patch are updated from the patch
+	DeviceVersion string `json:"device-version"`
+
+	// Required along with DeviceVersion for updates with constraints
+	DeviceModel      string `json:"device-model"`
+	HardwareRevision uint16 `json:"hardware-revision"`
+
+	// DeviceAddress must hold the license of licensed updates
+	DeviceAddress string `json:"device-address"`
+}
+
+// patchApplies reports whether a device running [deviceVersion] runs the
+// base release of the patch shipped with [update].
+func patchApplies(
+	ctx context.Context,
+	tcli *trpc.JSONRPCClient,
+	update *trpc.UpdateReply,
+	deviceVersion string,
+) bool {
+	if len(update.BaseUpdate) == 0 || len(deviceVersion) == 0 {
+		return false
+	}
+	version, err := storage.ParseSemVer(deviceVersion)
+	if err != nil {
+		return false
+	}
+	baseID, err := ids.FromString(update.BaseUpdate)
+	if err != nil {
+		return false
+	}
+	base, err := tcli.Update(ctx, baseID, false)
+	if err != nil {
+		return false
+	}
+	baseVersion, err := storage.ParseSemVer(base.UpdateVersion)
+	if err != nil {
+		return false
+	}
+	return storage.CompareSemVer(version, baseVersion) == 0
+}
+
+// imageCachePath is where the image of [update] is kept after it was pushed,
+// later releases can then be rebuilt from a patch against it.
+func imageCachePath(update ids.ID) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return dir + "/firmware-" + update.String() + ".bin", nil
+}
+
+// patchImage rebuilds the image of [update] into [filePath] from the cached
+// image of its base release. Only the patch is downloaded, the rebuilt image
+// must match the full image digest on chain.
+func patchImage(ctx context.Context, store ArtifactStore, update *trpc.UpdateReply, filePath string) error {
+	baseID, err := ids.FromString(update.BaseUpdate)
+	if err != nil {
+		return err
+	}
+	basePath, err := imageCachePath(baseID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(basePath); err != nil {
+		return err
+	}
+	digest, err := storage.ParseDigestString(update.Digest)
+	if err != nil {
+		return err
+	}
+	patchDigest, err := storage.ParseDigestString(update.PatchDigest)
+	if err != nil {
+		return err
+	}
+
+	patchPath := filePath + ".patch"
+	if err := downloadArtifact(ctx, store, string(update.PatchIPFSUrl), patchPath); err != nil {
+		return err
+	}
+	defer os.Remove(patchPath)
+	if err := verifyFile(patchPath, patchDigest); err != nil {
+		return err
+	}
+	return ApplyPatch(basePath, patchPath, filePath, digest)
+}
+
+// checkAvailable writes an error to [w] and returns false if [update] is a
+// draft or outside its validity window. Drafts and embargoed updates are
+// reported as not released yet.
+func checkAvailable(w http.ResponseWriter, update *trpc.UpdateReply) bool {
+	switch {
+	case update.Draft:
+		http.Error(w, "Update has not been released", http.StatusNotFound)
+	case update.Available:
+		return true
+	case update.Expired:
+		http.Error(w, "Update has expired", http.StatusGone)
+	default:
+		http.Error(w, "Update is not available yet", http.StatusNotFound)
+	}
+	return false
+}
+
+// checkRollout writes an error to [w] and returns false if [device] is not
+// part of the staged rollout of [updateID]. Updates released to the whole
+// fleet skip the lookup.
+func checkRollout(
+	ctx context.Context,
+	tcli *trpc.JSONRPCClient,
+	w http.ResponseWriter,
+	updateID ids.ID,
+	device string,
+	percentage uint8,
+) bool {
+	if percentage >= storage.MaxRolloutPercentage {
+		return true
+	}
+	deviceID, err := ids.FromString(device)
+	if err != nil {
+		http.Error(w, "Device ID required for staged rollout", http.StatusBadRequest)
+		return false
+	}
+	rollout, err := tcli.RolloutEligibility(ctx, updateID, deviceID)
+	if err != nil {
+		http.Error(w, "Cannot query chain", http.StatusInternalServerError)
+		return false
+	}
+	if !rollout.Eligible {
+		http.Error(w, "Device is not part of the rollout", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// checkLicense writes an error to [w] and returns false if [address] does not
+// hold the license of [update]. Updates without a license are served to any
+// device.
+func checkLicense(
+	ctx context.Context,
+	tcli *trpc.JSONRPCClient,
+	w http.ResponseWriter,
+	update *trpc.UpdateReply,
+	address string,
+) bool {
+	if update.License == nil {
+		return true
+	}
+	if _, err := codec.ParseAddressBech32(consts.HRP, address); err != nil {
+		http.Error(w, "Device address required for licensed update", http.StatusBadRequest)
+		return false
+	}
+	balance, err := tcli.Balance(ctx, address, update.License.Asset)
+	if err != nil {
+		http.Error(w, "Cannot query chain", http.StatusInternalServerError)
+		return false
+	}
+	if balance < update.License.MinBalance {
+		http.Error(w, "Device does not hold the update license", http.StatusPaymentRequired)
+		return false
+	}
+	return true
+}
+
+// otaModes maps the artifacts devices can install over the air to the mode
+// of the OTA endpoint writing them
+var otaModes = map[string]string{
+	storage.ArtifactFilesystem.String(): "fs",
+	storage.ArtifactApp.String():        "fr",
+}
+
+// pushArtifacts installs the artifacts of a multi-artifact release on the
+// device in manifest order, except for the app which is pushed last by the
+// caller.
+func pushArtifacts(ctx context.Context, store ArtifactStore, update *trpc.UpdateReply, txid, filePath, deviceIp string, w http.ResponseWriter) error {
+	for i, artifact := range update.Manifest {
+		if artifact.Type == storage.ArtifactApp.String() {
+			continue
+		}
+		digest, err := storage.ParseDigestString(artifact.Digest)
+		if err != nil {
+			return err
+		}
+		artifactPath := filePath + "." + strconv.Itoa(i)
+		if err := downloadArtifact(ctx, store, artifact.IPFSUrl, artifactPath); err != nil {
+			return err
+		}
+		if err := verifyFile(artifactPath, digest); err != nil {
+			return err
+		}
+		if err := pushFirmwareHash(otaModes[artifact.Type], artifact.Digest, txid, artifactPath, deviceIp); err != nil {
+			deleteFile(artifactPath)
+			return err
+		}
+		if err := PushFirmwareUpdate(deviceIp, artifactPath, w); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func pushFirmwareHash(hash, txid, filePath, deviceIp string) error {
+func pushFirmwareHash(mode, hash, txid, filePath, deviceIp string) error {
 
-	url := "http://" + deviceIp + "/ota/start?mode=fr&hash=" + hash + "&txid=" + txid
+	url := "http://" + deviceIp + "/ota/start?mode=" + mode + "&hash=" + hash + "&txid=" + txid
 	fmt.Println(url)
 
 	// Create the request
@@ -386,7 +832,7 @@ func PushFirmwareUpdate(deviceIp string, filePath string, w http.ResponseWriter)
 	return nil
 }
 
-func PushUpdate(ctx context.Context) http.HandlerFunc {
+func PushUpdate(ctx context.Context, store ArtifactStore) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -405,20 +851,100 @@ func PushUpdate(ctx context.Context) http.HandlerFunc {
 
 		transactionId, err := ids.FromString(pushUpdateInfo.UpdateTx)
 
-		_, _, UpdateExecutableHash, UpdateIPFSUrl, _, _, _, err := tcli.Update(ctx, transactionId, false)
+		update, err := tcli.Update(ctx, transactionId, false)
 
 		if err != nil {
 			fmt.Fprintln(w, "Server Error")
 		}
 
-		err_download := downloadIPFSFile(filePath, trimNullChars(string(UpdateIPFSUrl)))
-
-		if err_download != nil {
-			fmt.Println("Error Downloading file:", err)
+		if update.Revoked {
+			http.Error(w, "Update has been revoked", http.StatusGone)
 			return
 		}
+		if !checkAvailable(w, update) {
+			return
+		}
+		if !checkRollout(ctx, tcli, w, transactionId, pushUpdateInfo.DeviceID, update.RolloutPercentage) {
+			return
+		}
+		if !checkLicense(ctx, tcli, w, update, pushUpdateInfo.DeviceAddress) {
+			return
+		}
+		// Builds restricted to some hardware or versions are only pushed to
+		// devices known to satisfy them
+		if update.Constraints != nil {
+			if len(pushUpdateInfo.DeviceVersion) == 0 {
+				http.Error(w, "Device version required for constrained update", http.StatusBadRequest)
+				return
+			}
+			applies, err := tcli.UpdateApplies(ctx, transactionId, pushUpdateInfo.DeviceModel, pushUpdateInfo.HardwareRevision, pushUpdateInfo.DeviceVersion, pushUpdateInfo.DeviceAddress)
+			if err != nil {
+				http.Error(w, "Cannot query chain", http.StatusInternalServerError)
+				return
+			}
+			if !applies.Applies {
+				http.Error(w, "Update does not apply to device: "+applies.Reason, http.StatusForbidden)
+				return
+			}
+		}
+		// Refuse releases the device can't fully install before flashing
+		// anything
+		for _, artifact := range update.Manifest {
+			if _, ok := otaModes[artifact.Type]; !ok {
+				http.Error(w, "Device cannot install "+artifact.Type+" artifacts over the air", http.StatusNotImplemented)
+				return
+			}
+		}
+
+		patched := false
+		if patchApplies(ctx, tcli, update, pushUpdateInfo.DeviceVersion) {
+			if err := patchImage(ctx, store, update, filePath); err != nil {
+				fmt.Println("Cannot rebuild image from patch, using the full image:", err)
+			} else {
+				patched = true
+			}
+		}
+		if !patched {
+			err_download := downloadArtifact(ctx, store, string(update.UpdateIPFSUrl), filePath)
+
+			if err_download != nil {
+				fmt.Println("Error Downloading file:", err_download)
+				http.Error(w, "Cannot download update: "+err_download.Error(), http.StatusBadGateway)
+				return
+			}
+			// The store is not trusted, only the digest on chain is
+			digest, err := storage.ParseDigestString(update.Digest)
+			if err != nil {
+				deleteFile(filePath)
+				http.Error(w, "Invalid update digest", http.StatusInternalServerError)
+				return
+			}
+			if err := verifyFile(filePath, digest); err != nil {
+				fmt.Println("Error Verifying file:", err)
+				http.Error(w, "Cannot verify update: "+err.Error(), http.StatusBadGateway)
+				return
+			}
+		}
 
-		err = pushFirmwareHash(trimNullChars(string(UpdateExecutableHash)), transactionId.String(), filePath, pushUpdateInfo.DeviceIp)
+		// Keep the image so the next release can be patched on top of it
+		if cachePath, err := imageCachePath(transactionId); err == nil {
+			if err := copyFile(cachePath, filePath); err != nil {
+				fmt.Println("Cannot cache image:", err)
+			}
+		}
+
+		// Devices only tell tagged digests apart from the legacy MD5 hex
+		hash := update.Digest
+		if update.DigestWeak {
+			hash = string(update.UpdateExecutableHash)
+		}
+		// Flashing the app reboots the device, everything else goes first
+		if err := pushArtifacts(ctx, store, update, transactionId.String(), filePath, pushUpdateInfo.DeviceIp, w); err != nil {
+			deleteFile(filePath)
+			http.Error(w, "Cannot push artifacts: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		err = pushFirmwareHash(otaModes[storage.ArtifactApp.String()], hash, transactionId.String(), filePath, pushUpdateInfo.DeviceIp)
 		if err != nil {
 			http.Error(w, "Cannot push hash to firmware: "+err.Error(), http.StatusInternalServerError)
 			return
@@ -436,6 +962,85 @@ func PushUpdate(ctx context.Context) http.HandlerFunc {
 
 }
 
+type ReportResultInfo struct {
+	UpdateTx  string `json:"update-tx"`
+	DeviceID  string `json:"device-id"`
+	Status    uint8  `json:"status"` // 1=success 2=failure 3=rolled-back
+	ErrorCode uint32 `json:"error-code"`
+}
+
+// ReportResult lets devices that hold no key report the outcome of an OTA
+// attempt, the server submits the result on their behalf. The server key must
+// hold the report role on the update's project.
+func ReportResult(ctx context.Context) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			http.Error(w, "Cannot load key", http.StatusInternalServerError)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Error reading request body", http.StatusBadRequest)
+			return
+		}
+
+		var reportResultInfo ReportResultInfo
+		if err := json.Unmarshal(body, &reportResultInfo); err != nil {
+			http.Error(w, "Error decoding JSON", http.StatusBadRequest)
+			return
+		}
+
+		transactionId, err := ids.FromString(reportResultInfo.UpdateTx)
+		if err != nil {
+			http.Error(w, "Invalid TxId", http.StatusBadRequest)
+			return
+		}
+
+		deviceID, err := ids.FromString(reportResultInfo.DeviceID)
+		if err != nil {
+			http.Error(w, "Invalid Device ID", http.StatusBadRequest)
+			return
+		}
+
+		update, err := tcli.Update(ctx, transactionId, false)
+		if err != nil {
+			http.Error(w, "Update not found", http.StatusNotFound)
+			return
+		}
+		project, err := actions.ParseProjectID(update.ProjectTxID)
+		if err != nil {
+			http.Error(w, "Invalid Project", http.StatusInternalServerError)
+			return
+		}
+
+		report := &actions.ReportUpdateResult{
+			Project:   project,
+			Update:    transactionId,
+			Device:    deviceID,
+			Status:    reportResultInfo.Status,
+			ErrorCode: reportResultInfo.ErrorCode,
+		}
+
+		success, id, err := sendAndWait(ctx, nil, report, cli, scli, tcli, factory, true)
+		if err != nil {
+			http.Error(w, "Error while reporting result", http.StatusInternalServerError)
+			return
+		}
+		if !success {
+			http.Error(w, "Result rejected by chain", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(id.String())
+	}
+
+}
+
 func GetUpdate(ctx context.Context) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -445,12 +1050,10 @@ func GetUpdate(ctx context.Context) http.HandlerFunc {
 		t := r.URL.Query().Get("transactionid")
 		transactionId, _ := ids.FromString(t)
 
-		_, ProjectTxID, UpdateExecutableHash, UpdateIPFSUrl, ForDeviceName, UpdateVersion, _, _ := tcli.Update(ctx, transactionId, false)
-
-		uversion, _ := strconv.ParseUint(trimNullChars(string(UpdateVersion)), 10, 8)
+		update, _ := tcli.Update(ctx, transactionId, false)
 
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("Project Id: " + trimNullChars(string(ProjectTxID)) + "\n Hash: " + trimNullChars(string(UpdateExecutableHash)) + "\n IPFS URL: " + trimNullChars(string(UpdateIPFSUrl)) + "\n Device Name: " + trimNullChars(string(ForDeviceName)) + "\n VersionL " + string(uversion)))
+		w.Write([]byte("Project Id: " + string(update.ProjectTxID) + "\n Hash: " + update.Digest + "\n IPFS URL: " + string(update.UpdateIPFSUrl) + "\n Device Name: " + string(update.ForDeviceName) + "\n Version: " + update.UpdateVersion + "\n Revoked: " + strconv.FormatBool(update.Revoked)))
 	}
 
 }
@@ -461,12 +1064,54 @@ var startServer = &cobra.Command{
 
 		ctx := context.Background()
 
+		store, err := loadArtifactStore()
+		if err != nil {
+			return err
+		}
+
+		// Devices are notified of new updates over MQTT when a broker is
+		// configured
+		var (
+			publisher *mqttPublisher
+			watched   = set.Set[ids.ID]{}
+		)
+		if len(mqttBroker) > 0 {
+			publisher, err = newMQTTPublisher(mqttBroker, mqttClientID, mqttUsername, mqttPassword)
+			if err != nil {
+				return err
+			}
+			defer publisher.Close()
+			fmt.Println("Publishing update notifications to", mqttBroker)
+
+			for _, project := range mqttWatch {
+				id, err := ids.FromString(project)
+				if err != nil {
+					return err
+				}
+				watched.Add(id)
+			}
+		}
+		if watched.Len() > 0 {
+			_, _, _, _, scli, tcli, err := handler.DefaultActor()
+			if err != nil {
+				return err
+			}
+			go func() {
+				if err := watchProjects(ctx, tcli, scli, publisher, watched); err != nil {
+					fmt.Println("Stopped watching projects:", err)
+				}
+			}()
+		}
+
 		http.HandleFunc("/", GetUpdateDataHandler(ctx))
 		http.HandleFunc("/create-repository", CreateRepositoryHandler(ctx))
-		http.HandleFunc("/create-update", CreateUpdateHandler(ctx))
+		http.HandleFunc("/create-update", CreateUpdateHandler(ctx, store, publisher, watched))
 		http.HandleFunc("/check-hash", GetUpdateHash(ctx))
-		http.HandleFunc("/push-update", PushUpdate(ctx))
+		http.HandleFunc("/push-update", PushUpdate(ctx, store))
 		http.HandleFunc("/get-update", GetUpdate(ctx))
+		http.HandleFunc("/latest-update", GetLatestUpdateHandler(ctx))
+		http.HandleFunc("/next-update", NextUpdateHandler(ctx))
+		http.HandleFunc("/report-result", ReportResult(ctx))
 
 		// Start the HTTP server on port 8080
 		fmt.Println("Server is listening on port 8080...")
@@ -2,11 +2,27 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"hyper-updates/actions"
 	"hyper-updates/consts"
+	trpc "hyper-updates/rpc"
+	"hyper-updates/storage"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+	"github.com/ava-labs/hypersdk/pubsub"
+	"github.com/ava-labs/hypersdk/rpc"
+	"github.com/ava-labs/hypersdk/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -83,18 +99,102 @@ var getRepoCmd = &cobra.Command{
 
 		id, err := handler.Root().PromptID("Project txid")
 
-		ID, ProjectName, ProjectDescription, ProjectOwner, Logo, err := tcli.Project(ctx, id, false)
+		project, err := tcli.Project(ctx, id, false)
 
-		addr, err := codec.AddressBech32(consts.HRP, codec.Address(ID))
-		// owner, err := codec.AddressBech32(consts.HRP, codec.Address(ProjectOwner))
+		addr, err := codec.AddressBech32(consts.HRP, codec.Address(project.ID))
 
-		fmt.Println("Id: ", addr, ", Project Name: ", string(ProjectName), ", Project Logo: ", string(Logo), ", Project Description: ", string(ProjectDescription), ", Project Owner: ", string(ProjectOwner))
+		fmt.Println("Id: ", addr, ", Project Name: ", string(project.ProjectName), ", Project Logo: ", string(project.Logo), ", Project Description: ", string(project.ProjectDescription), ", Project Owner: ", project.ProjectOwner)
+		if len(project.PendingOwner) > 0 {
+			fmt.Println("Pending Owner: ", project.PendingOwner)
+		}
+		if project.ApprovalThreshold > 0 {
+			fmt.Println("Approval Threshold: ", project.ApprovalThreshold)
+		}
+		if project.LicenseAsset != ids.Empty {
+			fmt.Println("License Asset: ", project.LicenseAsset)
+		}
+
+		return err
+
+	},
+}
+
+var editRepoCmd = &cobra.Command{
+	Use: "edit-repository",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		URL, err := handler.Root().PromptString("Project Logo URL", 0, actions.ProjectLogoUnits)
+		if err != nil {
+			return err
+		}
+
+		project_description, err := handler.Root().PromptString("Project Description", 1, actions.ProjectDescriptionUnits)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.EditProject{
+			Project:            project,
+			ProjectDescription: []byte(project_description),
+			Logo:               []byte(URL),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while editing the project")
+		}
+
+		fmt.Println(id)
 
 		return err
 
 	},
 }
 
+var getRepoHistoryCmd = &cobra.Command{
+	Use: "get-repository-history",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		revisions, err := tcli.ProjectHistory(ctx, project, 0)
+		if err != nil {
+			return err
+		}
+		for _, revision := range revisions {
+			fmt.Println("Tx Id: ", revision.TxID, ", Timestamp: ", revision.Timestamp, ", Project Logo: ", string(revision.Logo), ", Project Description: ", string(revision.Description))
+		}
+
+		return nil
+
+	},
+}
+
 var createUpdateCmd = &cobra.Command{
 	Use: "push-update",
 	RunE: func(*cobra.Command, []string) error {
@@ -105,6 +205,38 @@ var createUpdateCmd = &cobra.Command{
 			return err
 		}
 
+		channel, err := parseChannel(releaseChannel)
+		if err != nil {
+			return err
+		}
+		if rolloutPercentage < 0 || rolloutPercentage > int(storage.MaxRolloutPercentage) {
+			return ErrInvalidRollout
+		}
+		algorithm, err := storage.ParseDigestAlgorithm(digestAlgorithm)
+		if err != nil {
+			return err
+		}
+		signature, err := hex.DecodeString(updateSignature)
+		if err != nil {
+			return err
+		}
+		constraints, err := parseConstraints()
+		if err != nil {
+			return err
+		}
+		window, err := parseUpdateWindow(notBefore, expiresAt)
+		if err != nil {
+			return err
+		}
+		license, err := parseLicense()
+		if err != nil {
+			return err
+		}
+		store, err := loadArtifactStore()
+		if err != nil {
+			return err
+		}
+
 		project_id, err := handler.Root().PromptString("Project txid", 1, 100)
 		if err != nil {
 			return err
@@ -115,41 +247,72 @@ var createUpdateCmd = &cobra.Command{
 			return err
 		}
 
-		executable_ipfs_url, err := DeployBin(
-			executable_path,
-			"fc43a725fd778580045c",
-			"37c52b3571d7df2c1326c1460a1b192c209a1fb212c6b1b96eb2626bb2076efe",
-		)
+		executable_ipfs_url, err := store.Put(ctx, executable_path)
 		if err != nil {
 			return err
 		}
 
 		fmt.Println("Binary Upload completed")
 
-		executable_hash, err := CalculateMD5(executable_path)
+		executable_hash, err := CalculateDigest(executable_path, algorithm)
 		if err != nil {
 			return err
 		}
 
-		fmt.Println("Hash Calculated")
+		fmt.Println("Hash Calculated:", executable_hash)
 
 		for_device_name, err := handler.Root().PromptString("Update For Device (Name)", 1, 100)
 		if err != nil {
 			return err
 		}
 
-		version, err := handler.Root().PromptInt("Update Version", 10)
+		version, err := promptVersion("Update Version")
 		if err != nil {
 			return err
 		}
 
+		// The full image is published either way for devices that don't run
+		// the base release
+		var patch storage.UpdatePatch
+		if len(baseUpdate) > 0 {
+			patch, err = createUpdatePatch(ctx, tcli, store, baseUpdate, executable_path, algorithm)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The executable is the app of a multi-artifact release
+		var manifest storage.Manifest
+		if len(manifestPath) > 0 {
+			app, err := FileArtifact(storage.ArtifactApp, executable_path, algorithm)
+			if err != nil {
+				return err
+			}
+			app.IPFSUrl = []byte(executable_ipfs_url)
+			manifest, err = BuildManifest(ctx, manifestPath, app, algorithm, store)
+			if err != nil {
+				return err
+			}
+			fmt.Println("Manifest Hash:", manifest.Digest())
+		}
+
 		update := &actions.CreateUpdate{
 			ProjectTxID:          []byte(project_id),
-			UpdateExecutableHash: []byte(executable_hash),
+			UpdateExecutableHash: executable_hash.Bytes(),
 			UpdateIPFSUrl:        []byte(executable_ipfs_url),
 			ForDeviceName:        []byte(for_device_name),
-			UpdateVersion:        uint8(version),
-			SuccessCount:         0,
+			UpdateVersion:        []byte(version),
+			Channel:              channel,
+			RolloutPercentage:    uint8(rolloutPercentage),
+			Signature:            signature,
+			BaseUpdate:           patch.BaseUpdate,
+			PatchHash:            patch.PatchHash,
+			PatchIPFSUrl:         patch.PatchIPFSUrl,
+			Manifest:             manifest,
+			Constraints:          constraints,
+			NotBefore:            window.NotBefore,
+			ExpiresAt:            window.ExpiresAt,
+			License:              license,
 		}
 
 		// Generate transaction
@@ -166,6 +329,129 @@ var createUpdateCmd = &cobra.Command{
 	},
 }
 
+// parseConstraints builds the update constraints from the push-update flags.
+func parseConstraints() (storage.UpdateConstraints, error) {
+	var constraints storage.UpdateConstraints
+	for _, board := range updateBoards {
+		constraints.Boards = append(constraints.Boards, []byte(board))
+	}
+	if minHardwareRevision < 0 || minHardwareRevision > math.MaxUint16 {
+		return storage.UpdateConstraints{}, ErrInvalidRevision
+	}
+	constraints.MinHardwareRevision = uint16(minHardwareRevision)
+	var err error
+	if len(minSourceVersion) > 0 {
+		if constraints.MinSourceVersion, err = storage.ParseSemVer(minSourceVersion); err != nil {
+			return storage.UpdateConstraints{}, err
+		}
+	}
+	if len(maxSourceVersion) > 0 {
+		if constraints.MaxSourceVersion, err = storage.ParseSemVer(maxSourceVersion); err != nil {
+			return storage.UpdateConstraints{}, err
+		}
+	}
+	if len(passThrough) > 0 {
+		if constraints.PassThrough, err = ids.FromString(passThrough); err != nil {
+			return storage.UpdateConstraints{}, err
+		}
+	}
+	return constraints, constraints.Validate()
+}
+
+// parseUpdateWindow reads the RFC 3339 bounds of an update validity window,
+// empty bounds are left open.
+func parseUpdateWindow(notBefore, expiresAt string) (storage.UpdateWindow, error) {
+	var window storage.UpdateWindow
+	if len(notBefore) > 0 {
+		t, err := time.Parse(time.RFC3339, notBefore)
+		if err != nil {
+			return storage.UpdateWindow{}, err
+		}
+		window.NotBefore = t.UnixMilli()
+	}
+	if len(expiresAt) > 0 {
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return storage.UpdateWindow{}, err
+		}
+		window.ExpiresAt = t.UnixMilli()
+	}
+	return window, window.Validate()
+}
+
+// parseLicense builds the update license from the push-update flags, updates
+// without a license asset are free.
+func parseLicense() (storage.UpdateLicense, error) {
+	if len(licenseAsset) == 0 {
+		return storage.UpdateLicense{}, nil
+	}
+	asset, err := ids.FromString(licenseAsset)
+	if err != nil {
+		return storage.UpdateLicense{}, err
+	}
+	if licenseMinBalance == 0 {
+		return storage.UpdateLicense{}, ErrInvalidLicense
+	}
+	return storage.UpdateLicense{Asset: asset, MinBalance: licenseMinBalance}, nil
+}
+
+// createUpdatePatch uploads a patch that rebuilds [executablePath] from the
+// image of the [base] update. The base image is checked against its digest on
+// chain first, a patch made against any other build could not be applied.
+func createUpdatePatch(
+	ctx context.Context,
+	tcli *trpc.JSONRPCClient,
+	store ArtifactStore,
+	base string,
+	executablePath string,
+	algorithm storage.DigestAlgorithm,
+) (storage.UpdatePatch, error) {
+	baseID, err := ids.FromString(base)
+	if err != nil {
+		return storage.UpdatePatch{}, err
+	}
+	baseUpdate, err := tcli.Update(ctx, baseID, false)
+	if err != nil {
+		return storage.UpdatePatch{}, err
+	}
+	baseDigest, err := storage.ParseDigestString(baseUpdate.Digest)
+	if err != nil {
+		return storage.UpdatePatch{}, err
+	}
+
+	basePath := executablePath + ".base"
+	if err := downloadArtifact(ctx, store, string(baseUpdate.UpdateIPFSUrl), basePath); err != nil {
+		return storage.UpdatePatch{}, err
+	}
+	defer os.Remove(basePath)
+	if err := verifyFile(basePath, baseDigest); err != nil {
+		return storage.UpdatePatch{}, err
+	}
+
+	patchPath := executablePath + ".patch"
+	if err := CreatePatch(basePath, executablePath, patchPath); err != nil {
+		return storage.UpdatePatch{}, err
+	}
+	defer os.Remove(patchPath)
+
+	patchURL, err := store.Put(ctx, patchPath)
+	if err != nil {
+		return storage.UpdatePatch{}, err
+	}
+	patchDigest, err := CalculateDigest(patchPath, algorithm)
+	if err != nil {
+		return storage.UpdatePatch{}, err
+	}
+
+	fmt.Println("Patch Upload completed:", patchDigest)
+
+	return storage.UpdatePatch{
+		BaseUpdate:   baseID,
+		PatchHash:    patchDigest.Bytes(),
+		PatchIPFSUrl: []byte(patchURL),
+	}, nil
+}
+
 var getUpdateCmd = &cobra.Command{
 	Use: "get-update",
 	RunE: func(*cobra.Command, []string) error {
@@ -178,13 +464,1944 @@ var getUpdateCmd = &cobra.Command{
 
 		id, err := handler.Root().PromptID("Update txid")
 
-		ID, ProjectTxID, UpdateExecutableHash, UpdateIPFSUrl, ForDeviceName, UpdateVersion, SuccessCount, err := tcli.Update(ctx, id, false)
+		update, err := tcli.Update(ctx, id, false)
 
-		addr, err := codec.AddressBech32(consts.HRP, codec.Address(ID))
+		addr, err := codec.AddressBech32(consts.HRP, codec.Address(update.ID))
 
-		fmt.Println("Id: ", addr, ", Project Tx Id: ", string(ProjectTxID), ", Exe Hash: ", string(UpdateExecutableHash), ", Ipfs URL: ", string(UpdateIPFSUrl), ", For Devide: ", string(ForDeviceName), ", Version: ", UpdateVersion, ", Success: ", SuccessCount)
+		fmt.Println("Id: ", addr, ", Project Tx Id: ", string(update.ProjectTxID), ", Exe Hash: ", update.Digest, ", Ipfs URL: ", string(update.UpdateIPFSUrl), ", For Devide: ", string(update.ForDeviceName), ", Version: ", update.UpdateVersion, ", Channel: ", formatChannel(update.Channel), ", Rollout: ", update.RolloutPercentage, "%, Success: ", update.SuccessCount, ", Failure: ", update.FailureCount)
+		if update.DigestWeak {
+			fmt.Println("Warning: the executable hash is a legacy MD5 digest")
+		}
+		if len(update.Signature) > 0 {
+			fmt.Println("Signature: ", update.Signature)
+		}
+		if len(update.BaseUpdate) > 0 {
+			fmt.Println("Patch Base: ", update.BaseUpdate, ", Patch Hash: ", update.PatchDigest, ", Patch Ipfs URL: ", string(update.PatchIPFSUrl))
+		}
+		if c := update.Constraints; c != nil {
+			fmt.Println("Boards: ", strings.Join(c.Boards, ", "), ", Min Hardware Revision: ", c.MinHardwareRevision, ", Source Versions: ", c.MinSourceVersion, "-", c.MaxSourceVersion, ", Pass-through: ", c.PassThrough)
+		}
+		if len(update.Manifest) > 0 {
+			fmt.Println("Manifest Hash: ", update.ManifestDigest)
+			for i, artifact := range update.Manifest {
+				fmt.Println(i+1, ". Type: ", artifact.Type, ", Partition: ", artifact.Partition, ", Offset: ", artifact.Offset, ", Size: ", artifact.Size, ", Hash: ", artifact.Digest, ", Ipfs URL: ", artifact.IPFSUrl)
+			}
+		}
+		if update.Draft {
+			fmt.Println("Draft: awaiting approval, see get-approvals")
+		}
+		if update.License != nil {
+			fmt.Println("License Asset: ", update.License.Asset, ", Min Balance: ", update.License.MinBalance)
+		}
+		if update.Origin != nil {
+			fmt.Println("Imported From Chain: ", update.Origin.SourceChainID, ", Original Tx Id: ", update.Origin.SourceTxID, ", Original Project: ", update.Origin.SourceProject)
+		}
+		if update.NotBefore > 0 || update.ExpiresAt > 0 {
+			fmt.Println("Not Before: ", formatTimestamp(update.NotBefore), ", Expires At: ", formatTimestamp(update.ExpiresAt), ", Available: ", update.Available)
+		}
+		if update.Revoked {
+			fmt.Println("Revoked: ", formatRevokeReason(update.RevokeReason), ", Note: ", update.RevokeNote, ", At: ", update.RevokedAt)
+		}
 
 		return err
 
 	},
 }
+
+var getLatestUpdateCmd = &cobra.Command{
+	Use: "get-latest-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptString("Update For Device (Name)", 1, 100)
+		if err != nil {
+			return err
+		}
+
+		channel, err := promptChannel()
+		if err != nil {
+			return err
+		}
+
+		id, update, err := tcli.LatestUpdate(ctx, project, device, channel)
+		if err != nil {
+			return err
+		}
+		if id == ids.Empty {
+			fmt.Println("No update released for device")
+			return nil
+		}
+
+		fmt.Println("Update Tx Id: ", id, ", Exe Hash: ", update.Digest, ", Ipfs URL: ", string(update.UpdateIPFSUrl), ", Version: ", update.UpdateVersion)
+		if !update.Available {
+			fmt.Println("Warning: the update is outside its validity window, Not Before: ", formatTimestamp(update.NotBefore), ", Expires At: ", formatTimestamp(update.ExpiresAt))
+		}
+
+		return nil
+
+	},
+}
+
+var nextUpdateCmd = &cobra.Command{
+	Use: "next-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptString("Update For Device (Name)", 1, 100)
+		if err != nil {
+			return err
+		}
+
+		channel, err := promptChannel()
+		if err != nil {
+			return err
+		}
+
+		model, err := handler.Root().PromptString("Device model", 0, storage.MaxDeviceModelLen)
+		if err != nil {
+			return err
+		}
+
+		revision, err := handler.Root().PromptInt("Hardware revision", math.MaxUint16)
+		if err != nil {
+			return err
+		}
+
+		version, err := promptVersion("Device version")
+		if err != nil {
+			return err
+		}
+
+		// Only devices holding the license are offered licensed updates
+		address, err := handler.Root().PromptString("Device address (empty if unlicensed)", 0, 100)
+		if err != nil {
+			return err
+		}
+
+		next, err := tcli.NextUpdate(ctx, project, device, channel, model, uint16(revision), version, address)
+		if err != nil {
+			return err
+		}
+		if next.UpdateID == ids.Empty {
+			fmt.Println("No update applies:", next.Reason)
+			return nil
+		}
+
+		fmt.Println("Update Tx Id: ", next.UpdateID, ", Exe Hash: ", next.Update.Digest, ", Ipfs URL: ", string(next.Update.UpdateIPFSUrl), ", Version: ", next.Update.UpdateVersion)
+
+		return nil
+
+	},
+}
+
+// parseChannel maps a channel name to its code.
+func parseChannel(name string) (uint8, error) {
+	switch name {
+	case "stable":
+		return actions.ChannelStable, nil
+	case "beta":
+		return actions.ChannelBeta, nil
+	case "canary":
+		return actions.ChannelCanary, nil
+	default:
+		return 0, ErrInvalidChannel
+	}
+}
+
+func formatChannel(channel uint8) string {
+	switch channel {
+	case actions.ChannelStable:
+		return "stable"
+	case actions.ChannelBeta:
+		return "beta"
+	case actions.ChannelCanary:
+		return "canary"
+	default:
+		return "unknown"
+	}
+}
+
+func promptChannel() (uint8, error) {
+	name, err := handler.Root().PromptString("Channel (stable, beta, canary)", 1, 16)
+	if err != nil {
+		return 0, err
+	}
+	return parseChannel(name)
+}
+
+// promptVersion asks for a semantic version such as 2.10.1-rc.3.
+func promptVersion(label string) (string, error) {
+	version, err := handler.Root().PromptString(label, 1, actions.UpdateVersionUnits)
+	if err != nil {
+		return "", err
+	}
+	if _, err := storage.ParseSemVer(version); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func formatRoles(roles uint8) string {
+	names := []string{}
+	if roles&actions.RoleRelease != 0 {
+		names = append(names, "release")
+	}
+	if roles&actions.RoleYank != 0 {
+		names = append(names, "yank")
+	}
+	if roles&actions.RoleEditMetadata != 0 {
+		names = append(names, "edit-metadata")
+	}
+	if roles&actions.RoleReport != 0 {
+		names = append(names, "report")
+	}
+	if roles&actions.RoleApprove != 0 {
+		names = append(names, "approve")
+	}
+	return strings.Join(names, ",")
+}
+
+func formatRevokeReason(reason uint8) string {
+	switch reason {
+	case actions.RevokeReasonDefective:
+		return "defective"
+	case actions.RevokeReasonSecurity:
+		return "security"
+	case actions.RevokeReasonWrongArtifact:
+		return "wrong-artifact"
+	case actions.RevokeReasonSuperseded:
+		return "superseded"
+	case actions.RevokeReasonOther:
+		return "other"
+	default:
+		return "unknown"
+	}
+}
+
+func formatResultStatus(status uint8) string {
+	switch status {
+	case actions.ResultSuccess:
+		return "success"
+	case actions.ResultFailure:
+		return "failure"
+	case actions.ResultRolledBack:
+		return "rolled-back"
+	default:
+		return "unknown"
+	}
+}
+
+func formatDeviceStatus(status uint8) string {
+	switch status {
+	case actions.DeviceStatusActive:
+		return "active"
+	case actions.DeviceStatusOffline:
+		return "offline"
+	case actions.DeviceStatusFaulty:
+		return "faulty"
+	case actions.DeviceStatusDecommissioned:
+		return "decommissioned"
+	default:
+		return "unknown"
+	}
+}
+
+// formatTimestamp prints a window bound, zero bounds are open.
+func formatTimestamp(timestamp int64) string {
+	if timestamp == 0 {
+		return "none"
+	}
+	return time.UnixMilli(timestamp).UTC().Format(time.RFC3339)
+}
+
+func printDevice(device *trpc.Device) {
+	fmt.Println("Device Id: ", device.ID, ", Project Tx Id: ", device.Project, ", Public Key: ", device.PublicKey, ", Model: ", device.Model, ", Version: ", device.Version, ", Status: ", formatDeviceStatus(device.Status))
+}
+
+var addMaintainerCmd = &cobra.Command{
+	Use: "add-maintainer",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		maintainer, err := handler.Root().PromptAddress("Maintainer")
+		if err != nil {
+			return err
+		}
+
+		// Select roles to grant, any roles already held are replaced
+		var roles uint8
+		release, err := handler.Root().PromptBool("grant release")
+		if err != nil {
+			return err
+		}
+		if release {
+			roles |= actions.RoleRelease
+		}
+		yank, err := handler.Root().PromptBool("grant yank")
+		if err != nil {
+			return err
+		}
+		if yank {
+			roles |= actions.RoleYank
+		}
+		edit, err := handler.Root().PromptBool("grant edit metadata")
+		if err != nil {
+			return err
+		}
+		if edit {
+			roles |= actions.RoleEditMetadata
+		}
+		report, err := handler.Root().PromptBool("grant report")
+		if err != nil {
+			return err
+		}
+		if report {
+			roles |= actions.RoleReport
+		}
+		approve, err := handler.Root().PromptBool("grant approve")
+		if err != nil {
+			return err
+		}
+		if approve {
+			roles |= actions.RoleApprove
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.AddMaintainer{
+			Project:    project,
+			Maintainer: maintainer,
+			Roles:      roles,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while adding the maintainer")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var removeMaintainerCmd = &cobra.Command{
+	Use: "remove-maintainer",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		maintainer, err := handler.Root().PromptAddress("Maintainer")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.RemoveMaintainer{
+			Project:    project,
+			Maintainer: maintainer,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while removing the maintainer")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var getMaintainersCmd = &cobra.Command{
+	Use: "get-maintainers",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		maintainers, err := tcli.Maintainers(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if len(maintainers) == 0 {
+			fmt.Println("No maintainers")
+		}
+		for _, m := range maintainers {
+			fmt.Println("Maintainer: ", m.Address, ", Roles: ", formatRoles(m.Roles))
+		}
+
+		return nil
+
+	},
+}
+
+var revokeUpdateCmd = &cobra.Command{
+	Use: "revoke-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("reasons: 1=defective 2=security 3=wrong-artifact 4=superseded 5=other")
+		reason, err := handler.Root().PromptInt("Revoke reason", int(actions.MaxRevokeReason))
+		if err != nil {
+			return err
+		}
+
+		note, err := handler.Root().PromptString("Note", 0, actions.RevokeNoteUnits)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.RevokeUpdate{
+			Project: project,
+			Update:  update,
+			Reason:  uint8(reason),
+			Note:    []byte(note),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while revoking the update")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var reportResultCmd = &cobra.Command{
+	Use: "report-result",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptID("Device id")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("status: 1=success 2=failure 3=rolled-back")
+		status, err := handler.Root().PromptInt("Result status", int(actions.MaxResultStatus))
+		if err != nil {
+			return err
+		}
+
+		var errorCode int
+		if uint8(status) != actions.ResultSuccess {
+			errorCode, err = handler.Root().PromptInt("Error code", math.MaxUint32)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.ReportUpdateResult{
+			Project:   project,
+			Update:    update,
+			Device:    device,
+			Status:    uint8(status),
+			ErrorCode: uint32(errorCode),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while reporting the result")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var registerDeviceCmd = &cobra.Command{
+	Use: "register-device",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		rawKey, err := handler.Root().PromptString("Device Public Key (hex)", ed25519.PublicKeyLen*2, ed25519.PublicKeyLen*2)
+		if err != nil {
+			return err
+		}
+		keyBytes, err := hex.DecodeString(rawKey)
+		if err != nil {
+			return err
+		}
+		publicKey := ed25519.PublicKey(keyBytes)
+
+		model, err := handler.Root().PromptString("Device Model", 1, actions.DeviceModelUnits)
+		if err != nil {
+			return err
+		}
+
+		version, err := promptVersion("Current Version")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, _, err = sendAndWait(ctx, nil, &actions.RegisterDevice{
+			Project:   project,
+			PublicKey: publicKey,
+			Model:     []byte(model),
+			Version:   []byte(version),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while registering the device")
+		}
+
+		fmt.Println(storage.DeviceID(publicKey))
+
+		return err
+
+	},
+}
+
+var updateDeviceStatusCmd = &cobra.Command{
+	Use: "update-device-status",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptID("Device id")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("status: 1=active 2=offline 3=faulty")
+		status, err := handler.Root().PromptInt("Device status", int(actions.MaxReportableDeviceStatus))
+		if err != nil {
+			return err
+		}
+
+		// Leave empty to keep the current version
+		version, err := handler.Root().PromptString("Current Version (optional)", 0, actions.UpdateVersionUnits)
+		if err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.UpdateDeviceStatus{
+			Project: project,
+			Device:  device,
+			Status:  uint8(status),
+			Version: []byte(version),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while updating the device status")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var decommissionDeviceCmd = &cobra.Command{
+	Use: "decommission-device",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptID("Device id")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.DecommissionDevice{
+			Project: project,
+			Device:  device,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while decommissioning the device")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var getDeviceCmd = &cobra.Command{
+	Use: "get-device",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		id, err := handler.Root().PromptID("Device id")
+		if err != nil {
+			return err
+		}
+
+		device, err := tcli.Device(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		printDevice(device)
+
+		return nil
+
+	},
+}
+
+var getProjectDevicesCmd = &cobra.Command{
+	Use: "get-project-devices",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Walk every page of the fleet
+		start := ids.Empty
+		for {
+			devices, next, err := tcli.ProjectDevices(ctx, project, start, 0)
+			if err != nil {
+				return err
+			}
+			for _, device := range devices {
+				printDevice(device)
+			}
+			if next == ids.Empty {
+				return nil
+			}
+			start = next
+		}
+
+	},
+}
+
+var heartbeatCmd = &cobra.Command{
+	Use: "heartbeat",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		// Heartbeats must be signed with the device key
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptID("Device id")
+		if err != nil {
+			return err
+		}
+
+		// Leave empty when the running firmware was not released through
+		// the project
+		updateStr, err := handler.Root().PromptString("Running update txid (optional)", 0, 64)
+		if err != nil {
+			return err
+		}
+		update := ids.Empty
+		if len(updateStr) > 0 {
+			if update, err = ids.FromString(updateStr); err != nil {
+				return err
+			}
+		}
+
+		version, err := handler.Root().PromptString("Running Version", 1, actions.UpdateVersionUnits)
+		if err != nil {
+			return err
+		}
+
+		uptime, err := handler.Root().PromptInt("Uptime (seconds)", math.MaxInt)
+		if err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.DeviceHeartbeat{
+			Project: project,
+			Device:  device,
+			Update:  update,
+			Version: []byte(version),
+			Uptime:  uint64(uptime),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while sending the heartbeat")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var fleetStatusCmd = &cobra.Command{
+	Use: "fleet-status",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		devices, versions, stale, err := tcli.FleetStatus(ctx, project, staleMinutes)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Devices: ", devices)
+
+		// Most deployed versions first
+		running := make([]string, 0, len(versions))
+		for version := range versions {
+			running = append(running, version)
+		}
+		sort.Slice(running, func(i, j int) bool {
+			if versions[running[i]] != versions[running[j]] {
+				return versions[running[i]] > versions[running[j]]
+			}
+			return running[i] < running[j]
+		})
+		for _, version := range running {
+			fmt.Println("Version: ", version, ", Devices: ", versions[version])
+		}
+
+		fmt.Println("Stale Devices: ", len(stale))
+		for _, device := range stale {
+			fmt.Println("Device Id: ", device.ID, ", Version: ", device.Version, ", Update: ", device.Update, ", Last Seen: ", time.UnixMilli(device.LastSeen).Format(time.RFC3339))
+		}
+
+		return nil
+
+	},
+}
+
+var listRepositoriesCmd = &cobra.Command{
+	Use: "list-repositories",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		owner, err := handler.Root().PromptAddress("Owner")
+		if err != nil {
+			return err
+		}
+
+		// Walk every page of the owner's projects
+		cursor := ids.Empty
+		for {
+			projects, next, err := tcli.ListProjects(ctx, codec.MustAddressBech32(consts.HRP, owner), cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, project := range projects {
+				fmt.Println("Project Tx Id: ", project.ProjectID, ", Name: ", string(project.Project.ProjectName), ", Description: ", string(project.Project.ProjectDescription))
+			}
+			if next == ids.Empty {
+				return nil
+			}
+			cursor = next
+		}
+
+	},
+}
+
+var listUpdatesCmd = &cobra.Command{
+	Use: "list-updates",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Walk every page of the project's releases
+		cursor := ""
+		for {
+			updates, next, err := tcli.ListUpdates(ctx, project, cursor, 0)
+			if err != nil {
+				return err
+			}
+			for _, entry := range updates {
+				update := entry.Update
+				fmt.Println("Update Tx Id: ", entry.UpdateID, ", For Devide: ", string(update.ForDeviceName), ", Version: ", update.UpdateVersion, ", Channel: ", formatChannel(update.Channel), ", Released: ", time.UnixMilli(entry.Timestamp).Format(time.RFC3339), ", Revoked: ", update.Revoked)
+			}
+			if len(next) == 0 {
+				return nil
+			}
+			cursor = next
+		}
+
+	},
+}
+
+var promoteUpdateCmd = &cobra.Command{
+	Use: "promote-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptString("Update For Device (Name)", 1, actions.ForDeviceNameUnits)
+		if err != nil {
+			return err
+		}
+
+		channel, err := promptChannel()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.PromoteUpdate{
+			Project:       project,
+			Update:        update,
+			ForDeviceName: []byte(device),
+			Channel:       channel,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while promoting the update")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var setApprovalThresholdCmd = &cobra.Command{
+	Use: "set-approval-threshold",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		threshold, err := handler.Root().PromptInt("Approval threshold (0 to release on creation)", storage.MaxApprovalThreshold)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.SetApprovalThreshold{
+			Project:   project,
+			Threshold: uint8(threshold),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while setting the approval threshold")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var approveUpdateCmd = &cobra.Command{
+	Use: "approve-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		updateID, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		// The device and channel select the pointer moved on release
+		update, err := tcli.Update(ctx, updateID, false)
+		if err != nil {
+			return err
+		}
+		fmt.Println("For Devide: ", string(update.ForDeviceName), ", Version: ", update.UpdateVersion, ", Channel: ", formatChannel(update.Channel), ", Hash: ", update.Digest)
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.ApproveUpdate{
+			Project:       project,
+			Update:        updateID,
+			ForDeviceName: update.ForDeviceName,
+			Channel:       update.Channel,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while approving the update")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var getApprovalsCmd = &cobra.Command{
+	Use: "get-approvals",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		approvals, err := tcli.Approvals(ctx, update)
+		if err != nil {
+			return err
+		}
+		if approvals.Threshold == 0 {
+			fmt.Println("Update was released without approval")
+			return nil
+		}
+
+		fmt.Println("Author: ", approvals.Author, ", Threshold: ", approvals.Threshold, ", Draft: ", approvals.Draft, ", Released At: ", formatTimestamp(approvals.ReleasedAt))
+		for _, approval := range approvals.Approvals {
+			fmt.Println("Approver: ", approval.Approver, ", At: ", formatTimestamp(approval.Timestamp))
+		}
+
+		return nil
+
+	},
+}
+
+var setRolloutPercentageCmd = &cobra.Command{
+	Use: "set-rollout-percentage",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		percentage, err := handler.Root().PromptInt("Rollout percentage", int(storage.MaxRolloutPercentage))
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.SetRolloutPercentage{
+			Project:    project,
+			Update:     update,
+			Percentage: uint8(percentage),
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while setting the rollout percentage")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var checkRolloutCmd = &cobra.Command{
+	Use: "check-rollout",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		device, err := handler.Root().PromptID("Device ID")
+		if err != nil {
+			return err
+		}
+
+		rollout, err := tcli.RolloutEligibility(ctx, update, device)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Eligible: ", rollout.Eligible, ", Bucket: ", rollout.Bucket, ", Rollout: ", rollout.Percentage, "%")
+
+		return nil
+
+	},
+}
+
+var proposeProjectOwnerCmd = &cobra.Command{
+	Use: "propose-project-owner",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		owner, err := handler.Root().PromptAddress("New Owner")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.ProposeProjectOwner{
+			Project:  project,
+			NewOwner: owner,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while proposing the new owner")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var acceptProjectOwnerCmd = &cobra.Command{
+	Use: "accept-project-owner",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.AcceptProjectOwner{
+			Project: project,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while accepting the project")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var generateSigningKeyCmd = &cobra.Command{
+	Use: "generate-signing-key [path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+
+		// Signing keys are kept out of the CLI key store so they are never
+		// used to pay for transactions
+		p, err := ed25519.GeneratePrivateKey()
+		if err != nil {
+			return err
+		}
+		if err := utils.SaveBytes(args[0], p[:]); err != nil {
+			return err
+		}
+
+		pk := p.PublicKey()
+		fmt.Println("Signing Public Key: ", hex.EncodeToString(pk[:]))
+
+		return nil
+
+	},
+}
+
+var addSigningKeyCmd = &cobra.Command{
+	Use: "add-signing-key",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		publicKey, err := promptSigningKey()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.AddSigningKey{
+			Project:   project,
+			PublicKey: publicKey,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while adding the signing key")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var removeSigningKeyCmd = &cobra.Command{
+	Use: "remove-signing-key",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		publicKey, err := promptSigningKey()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.RemoveSigningKey{
+			Project:   project,
+			PublicKey: publicKey,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while removing the signing key")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var getSigningKeysCmd = &cobra.Command{
+	Use: "get-signing-keys",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		keys, err := tcli.SigningKeys(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if len(keys) == 0 {
+			fmt.Println("No signing keys, updates do not need to be signed")
+		}
+		for _, key := range keys {
+			fmt.Println("Signing Key: ", key)
+		}
+
+		return nil
+
+	},
+}
+
+var signUpdateCmd = &cobra.Command{
+	Use: "sign [signing key path]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+
+		// The signature is made offline, no chain access or funded key is
+		// needed
+		key, err := utils.LoadBytes(args[0], ed25519.PrivateKeyLen)
+		if err != nil {
+			return err
+		}
+		algorithm, err := storage.ParseDigestAlgorithm(digestAlgorithm)
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		executable_path, err := handler.Root().PromptString("Executable Path", 1, 500)
+		if err != nil {
+			return err
+		}
+
+		executable_hash, err := CalculateDigest(executable_path, algorithm)
+		if err != nil {
+			return err
+		}
+
+		for_device_name, err := handler.Root().PromptString("Update For Device (Name)", 1, 100)
+		if err != nil {
+			return err
+		}
+
+		version, err := promptVersion("Update Version")
+		if err != nil {
+			return err
+		}
+		semver, err := storage.ParseSemVer(version)
+		if err != nil {
+			return err
+		}
+
+		// Multi-artifact releases are signed over their manifest, push-update
+		// must be given the same manifest file
+		signed := executable_hash
+		if len(manifestPath) > 0 {
+			app, err := FileArtifact(storage.ArtifactApp, executable_path, algorithm)
+			if err != nil {
+				return err
+			}
+			manifest, err := BuildManifest(context.Background(), manifestPath, app, algorithm, nil)
+			if err != nil {
+				return err
+			}
+			signed = manifest.Digest()
+		}
+
+		msg := storage.UpdateSigningMessage(project, semver, []byte(for_device_name), signed.Bytes())
+		signature := ed25519.Sign(msg, ed25519.PrivateKey(key))
+
+		fmt.Println("Hash: ", signed)
+		fmt.Println("Signature: ", hex.EncodeToString(signature[:]))
+
+		return nil
+
+	},
+}
+
+var verifyUpdateSignatureCmd = &cobra.Command{
+	Use: "verify-update-signature",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		result, err := tcli.VerifyUpdateSignature(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		if !result.Signed {
+			fmt.Println("Update is not signed")
+			return nil
+		}
+		fmt.Println("Valid: ", result.Valid, ", Signing Key: ", result.SigningKey)
+
+		return nil
+
+	},
+}
+
+func promptSigningKey() (ed25519.PublicKey, error) {
+	rawKey, err := handler.Root().PromptString("Signing Public Key (hex)", ed25519.PublicKeyLen*2, ed25519.PublicKeyLen*2)
+	if err != nil {
+		return ed25519.EmptyPublicKey, err
+	}
+	keyBytes, err := hex.DecodeString(rawKey)
+	if err != nil {
+		return ed25519.EmptyPublicKey, err
+	}
+	return ed25519.PublicKey(keyBytes), nil
+}
+
+var setLicenseAssetCmd = &cobra.Command{
+	Use: "set-license-asset",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		// The license asset is minted by the project owner, create it with
+		// action create-asset first. The native asset clears the license
+		// asset.
+		asset, err := handler.Root().PromptAsset("License asset", true)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.SetLicenseAsset{
+			Project: project,
+			Asset:   asset,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while setting the license asset")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var mintLicensesCmd = &cobra.Command{
+	Use: "mint-licenses [device addresses file]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+
+		ctx := context.Background()
+		_, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+		reply, err := tcli.Project(ctx, project, false)
+		if err != nil {
+			return err
+		}
+		if reply.LicenseAsset == ids.Empty {
+			fmt.Println("Project has no license asset, see set-license-asset")
+			return nil
+		}
+
+		// One device address per line, blank lines are skipped
+		devices, err := readDeviceAddresses(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Println("Minting ", licenseAmount, " of ", reply.LicenseAsset, " to ", len(devices), " devices")
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		for _, device := range devices {
+			_, id, err := sendAndWait(ctx, nil, &actions.MintAsset{
+				To:    device,
+				Asset: reply.LicenseAsset,
+				Value: licenseAmount,
+			}, cli, scli, tcli, factory, false)
+			if err != nil {
+				fmt.Println("Error occured while minting the license of", codec.MustAddressBech32(consts.HRP, device))
+				return err
+			}
+			fmt.Println(codec.MustAddressBech32(consts.HRP, device), id)
+		}
+
+		return nil
+
+	},
+}
+
+// readDeviceAddresses reads the device addresses listed one per line in
+// [path].
+func readDeviceAddresses(path string) ([]codec.Address, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	devices := []codec.Address{}
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		device, err := codec.ParseAddressBech32(consts.HRP, line)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, line)
+		}
+		devices = append(devices, device)
+	}
+	return devices, nil
+}
+
+var exportUpdateCmd = &cobra.Command{
+	Use: "export-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		currentChainID, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		update, err := handler.Root().PromptID("Update txid")
+		if err != nil {
+			return err
+		}
+
+		destination, _, err := handler.Root().PromptChain("destination", set.Of(currentChainID))
+		if err != nil {
+			return err
+		}
+
+		// The destination project must list this project as an import
+		// source, see add-import-source
+		destinationProject, err := handler.Root().PromptID("Destination Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		success, id, err := sendAndWait(ctx, nil, &actions.ExportUpdate{
+			Project:            project,
+			Update:             update,
+			DestinationProject: destinationProject,
+			Destination:        destination,
+		}, cli, scli, tcli, factory, true)
+		if err != nil {
+			fmt.Println("Error occured while exporting the update")
+			return err
+		}
+		if !success {
+			return errors.New("not successful")
+		}
+
+		fmt.Println(id)
+
+		imp, err := handler.Root().PromptBool("perform import on destination")
+		if err != nil || !imp {
+			return err
+		}
+		uris, err := handler.Root().GetChain(destination)
+		if err != nil {
+			return err
+		}
+		networkID, _, _, err := cli.Network(ctx)
+		if err != nil {
+			return err
+		}
+		dscli, err := rpc.NewWebSocketClient(uris[0], rpc.DefaultHandshakeTimeout, pubsub.MaxPendingMessages, pubsub.MaxReadMessageSize)
+		if err != nil {
+			return err
+		}
+		return performUpdateImport(ctx, cli, rpc.NewJSONRPCClient(uris[0]), dscli, trpc.NewJSONRPCClient(uris[0], networkID, destination), id, factory)
+
+	},
+}
+
+var importUpdateCmd = &cobra.Command{
+	Use: "import-update",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		currentChainID, _, factory, dcli, dscli, dtcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		_, uris, err := handler.Root().PromptChain("sourceChainID", set.Of(currentChainID))
+		if err != nil {
+			return err
+		}
+		scli := rpc.NewJSONRPCClient(uris[0])
+
+		return performUpdateImport(ctx, scli, dcli, dscli, dtcli, ids.Empty, factory)
+
+	},
+}
+
+// performUpdateImport imports the update exported by [exportTxID] on the
+// chain of [scli] into the chain of the destination clients.
+func performUpdateImport(
+	ctx context.Context,
+	scli *rpc.JSONRPCClient,
+	dcli *rpc.JSONRPCClient,
+	dscli *rpc.WebSocketClient,
+	dtcli *trpc.JSONRPCClient,
+	exportTxID ids.ID,
+	factory chain.AuthFactory,
+) error {
+	var err error
+	if exportTxID == ids.Empty {
+		exportTxID, err = handler.Root().PromptID("export txID")
+		if err != nil {
+			return err
+		}
+	}
+
+	msg, subnetWeight, sigWeight, err := aggregateWarpSignature(ctx, scli, exportTxID)
+	if msg == nil || err != nil {
+		return err
+	}
+	wu, err := actions.UnmarshalWarpUpdate(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Project: ", wu.Project, ", For Devide: ", string(wu.ForDeviceName), ", Version: ", string(wu.UpdateVersion), ", Channel: ", formatChannel(wu.Channel), ", Origin: ", wu.Origin.SourceChainID, "/", wu.Origin.SourceTxID)
+	fmt.Println("Signature Weight: ", sigWeight, ", Total Weight: ", subnetWeight)
+
+	// Releases are only imported once per destination
+	existing, err := dtcli.ImportedUpdate(ctx, wu.Origin.SourceChainID, wu.Origin.SourceTxID)
+	if err != nil {
+		return err
+	}
+	if existing != ids.Empty {
+		fmt.Println("Update already imported as", existing)
+		return nil
+	}
+
+	// Confirm action
+	cont, err := handler.Root().PromptContinue()
+	if !cont || err != nil {
+		return err
+	}
+
+	_, id, err := sendAndWait(ctx, msg, &actions.ImportUpdate{}, dcli, dscli, dtcli, factory, true)
+	if err != nil {
+		fmt.Println("Error occured while importing the update")
+	}
+
+	fmt.Println(id)
+
+	return err
+}
+
+var addImportSourceCmd = &cobra.Command{
+	Use: "add-import-source",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		currentChainID, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		sourceChainID, _, err := handler.Root().PromptChain("source", set.Of(currentChainID))
+		if err != nil {
+			return err
+		}
+
+		sourceProject, err := handler.Root().PromptID("Source Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.AddImportSource{
+			Project:       project,
+			SourceChainID: sourceChainID,
+			SourceProject: sourceProject,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while adding the import source")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var removeImportSourceCmd = &cobra.Command{
+	Use: "remove-import-source",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		currentChainID, _, factory, cli, scli, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		sourceChainID, _, err := handler.Root().PromptChain("source", set.Of(currentChainID))
+		if err != nil {
+			return err
+		}
+
+		sourceProject, err := handler.Root().PromptID("Source Project txid")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := handler.Root().PromptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		_, id, err := sendAndWait(ctx, nil, &actions.RemoveImportSource{
+			Project:       project,
+			SourceChainID: sourceChainID,
+			SourceProject: sourceProject,
+		}, cli, scli, tcli, factory, true)
+
+		if err != nil {
+			fmt.Println("Error occured while removing the import source")
+		}
+
+		fmt.Println(id)
+
+		return err
+
+	},
+}
+
+var getImportSourcesCmd = &cobra.Command{
+	Use: "get-import-sources",
+	RunE: func(*cobra.Command, []string) error {
+
+		ctx := context.Background()
+		_, _, _, _, _, tcli, err := handler.DefaultActor()
+		if err != nil {
+			return err
+		}
+
+		project, err := handler.Root().PromptID("Project txid")
+		if err != nil {
+			return err
+		}
+
+		sources, err := tcli.ImportSources(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		if len(sources) == 0 {
+			fmt.Println("No import sources, updates cannot be imported")
+		}
+		for _, source := range sources {
+			fmt.Println("Source Chain: ", source.SourceChainID, ", Source Project: ", source.SourceProject)
+		}
+
+		return nil
+
+	},
+}
@@ -1,139 +1,153 @@
 package cmd
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"mime/multipart"
-	"net/http"
 	"os"
-	"path/filepath"
+	"sort"
 
-	"github.com/go-resty/resty/v2"
+	"hyper-updates/storage"
 
-	"crypto/md5"
-	"encoding/hex"
+	"github.com/gabstv/go-bsdiff/pkg/bsdiff"
+	"github.com/gabstv/go-bsdiff/pkg/bspatch"
 )
 
-type PinataResponse struct {
-	IpfsHash string `json:"IpfsHash"`
-}
+func CalculateDigest(filePath string, algorithm storage.DigestAlgorithm) (storage.Digest, error) {
 
-func DeployBin(filePath, apiKey, secretApiKey string) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return "", err
+		return storage.Digest{}, err
 	}
 	defer file.Close()
 
-	// Create a new buffer to store the multipart/form-data request body
-	var requestBody bytes.Buffer
-	writer := multipart.NewWriter(&requestBody)
+	return storage.ComputeDigest(algorithm, file)
+}
 
-	// Create a part for the file
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
-	if err != nil {
-		return "", err
-	}
+// ManifestEntry describes an artifact shipped next to the app in a manifest
+// file passed to push-update and sign.
+type ManifestEntry struct {
+	Type      string `json:"type"` // partition-table, bootloader or filesystem
+	File      string `json:"file"`
+	Partition string `json:"partition"`
+	Offset    uint32 `json:"offset"`
+}
 
-	// Copy the file content into the part
-	_, err = io.Copy(part, file)
+// BuildManifest reads the manifest file at [manifestPath] and returns its
+// artifacts and [app] in install order. Artifacts are uploaded to [store] if
+// set, otherwise they have no location, which is enough to sign them.
+func BuildManifest(
+	ctx context.Context,
+	manifestPath string,
+	app storage.Artifact,
+	algorithm storage.DigestAlgorithm,
+	store ArtifactStore,
+) (storage.Manifest, error) {
+	b, err := os.ReadFile(manifestPath)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+	var entries []ManifestEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, err
 	}
 
-	writer.Close()
+	manifest := make(storage.Manifest, 0, len(entries)+1)
+	for _, entry := range entries {
+		t, err := storage.ParseArtifactType(entry.Type)
+		if err != nil {
+			return nil, err
+		}
+		// The app is always the executable of the update
+		if t == storage.ArtifactApp {
+			return nil, ErrManifestApp
+		}
+		artifact, err := FileArtifact(t, entry.File, algorithm)
+		if err != nil {
+			return nil, err
+		}
+		artifact.Partition = []byte(entry.Partition)
+		artifact.Offset = entry.Offset
+		if store != nil {
+			url, err := store.Put(ctx, entry.File)
+			if err != nil {
+				return nil, err
+			}
+			artifact.IPFSUrl = []byte(url)
+			fmt.Println("Artifact Upload completed:", t, entry.File)
+		}
+		manifest = append(manifest, artifact)
+	}
+	manifest = append(manifest, app)
+	sort.SliceStable(manifest, func(i, j int) bool {
+		return manifest[i].Type < manifest[j].Type
+	})
+	return manifest, nil
+}
 
-	// Create the HTTP POST request
-	req, err := http.NewRequest("POST", "https://api.pinata.cloud/pinning/pinFileToIPFS", &requestBody)
+// FileArtifact returns an artifact of type [t] for the file at [filePath],
+// without partition or location.
+func FileArtifact(t storage.ArtifactType, filePath string, algorithm storage.DigestAlgorithm) (storage.Artifact, error) {
+	info, err := os.Stat(filePath)
 	if err != nil {
-		return "", err
+		return storage.Artifact{}, err
 	}
-
-	// Set content type header
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-
-	// Set API key headers
-	req.Header.Add("pinata_api_key", apiKey)
-	req.Header.Add("pinata_secret_api_key", secretApiKey)
-
-	// Perform the HTTP request
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	digest, err := CalculateDigest(filePath, algorithm)
 	if err != nil {
-		return "", err
+		return storage.Artifact{}, err
 	}
-	defer resp.Body.Close()
+	return storage.Artifact{
+		Type:   t,
+		Size:   uint64(info.Size()),
+		Digest: digest.Bytes(),
+	}, nil
+}
 
-	// Check the response status code
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API request failed with status code %d, %s", resp.StatusCode, body)
-	}
+// CreatePatch writes a bsdiff patch to [patchPath] that rebuilds [newPath]
+// from [basePath].
+func CreatePatch(basePath, newPath, patchPath string) error {
+	return bsdiff.File(basePath, newPath, patchPath)
+}
 
-	var pinataResponse PinataResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pinataResponse); err != nil {
-		return "", err
+// ApplyPatch rebuilds an image into [outPath] from [basePath] and
+// [patchPath]. The image is only kept if it matches [digest].
+func ApplyPatch(basePath, patchPath, outPath string, digest storage.Digest) error {
+	if err := bspatch.File(basePath, outPath, patchPath); err != nil {
+		return err
 	}
-
-	imageUrl := "https://ipfs.io/ipfs/" + pinataResponse.IpfsHash
-
-	return imageUrl, nil
+	return verifyFile(outPath, digest)
 }
 
-func CalculateMD5(filePath string) (string, error) {
-
-	file, err := os.Open(filePath)
+// verifyFile removes [filePath] if it does not match [digest].
+func verifyFile(filePath string, digest storage.Digest) error {
+	actual, err := CalculateDigest(filePath, digest.Algorithm)
 	if err != nil {
-		return "", err
+		return err
 	}
-	defer file.Close()
-
-	hash := md5.New()
-
-	if _, err := io.Copy(hash, file); err != nil {
-		return "", err
+	if !actual.Equal(digest) {
+		os.Remove(filePath)
+		return ErrDigestMismatch
 	}
-
-	hashInBytes := hash.Sum(nil)
-	hashString := hex.EncodeToString(hashInBytes)
-
-	return hashString, nil
+	return nil
 }
 
-func downloadIPFSFile(filepath string, url string) (err error) {
-
-	// Create a Resty client
-	client := resty.New()
-
-	// Perform the HTTP GET request
-	resp, err := client.R().
-		Get(url)
-
-	// Check for errors
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
 	if err != nil {
-		fmt.Println("Error:", err)
 		return err
 	}
+	defer in.Close()
 
-	// Check the response status code
-	if resp.StatusCode() != 200 {
-		fmt.Println("Error: Non-200 status code received")
-		return ErrInsufficientSupply
-	}
-
-	// Save the response body to a file
-	err = ioutil.WriteFile(filepath, resp.Body(), 0644)
+	out, err := os.Create(dst)
 	if err != nil {
-		fmt.Println("Error saving file:", err)
 		return err
 	}
-
-	fmt.Println("File downloaded successfully:", filepath)
-
-	return nil
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
 }
 
 func deleteFile(filePath string) error {
@@ -29,6 +29,7 @@ const (
 	defaultContinuousProfilerMaxFiles  = 10
 	defaultStoreTransactions           = true
 	defaultMaxOrdersPerPair            = 1024
+	defaultMaxFleetDevices             = 100_000
 )
 
 type Config struct {
@@ -67,6 +68,9 @@ type Config struct {
 	MaxOrdersPerPair int      `json:"maxOrdersPerPair"`
 	TrackedPairs     []string `json:"trackedPairs"` // which asset ID pairs we care about
 
+	// Fleet
+	MaxFleetDevices int `json:"maxFleetDevices"` // devices tracked from heartbeats across all projects
+
 	// Misc
 	VerifySignatures  bool          `json:"verifySignatures"`
 	StoreTransactions bool          `json:"storeTransactions"`
@@ -122,6 +126,7 @@ func (c *Config) setDefault() {
 	c.VerifySignatures = c.Config.GetVerifySignatures()
 	c.StoreTransactions = defaultStoreTransactions
 	c.MaxOrdersPerPair = defaultMaxOrdersPerPair
+	c.MaxFleetDevices = defaultMaxFleetDevices
 }
 
 func (c *Config) GetLogLevel() logging.Level                { return c.LogLevel }
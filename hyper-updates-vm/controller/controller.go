@@ -23,6 +23,7 @@ import (
 	"hyper-updates/auth"
 	"hyper-updates/config"
 	"hyper-updates/consts"
+	"hyper-updates/fleet"
 	"hyper-updates/genesis"
 	"hyper-updates/orderbook"
 	"hyper-updates/rpc"
@@ -45,6 +46,7 @@ type Controller struct {
 	metaDB database.Database
 
 	orderBook *orderbook.OrderBook
+	fleet     *fleet.Fleet
 }
 
 func New() *vm.VM {
@@ -145,6 +147,9 @@ func (c *Controller) Initialize(
 
 	// Initialize order book used to track all open orders
 	c.orderBook = orderbook.New(c, c.config.TrackedPairs, c.config.MaxOrdersPerPair)
+
+	// Initialize fleet index used to track the versions devices run
+	c.fleet = fleet.New(c, c.config.MaxFleetDevices)
 	return c.config, c.genesis, build, gossip, blockDB, stateDB, apis, consts.ActionRegistry, consts.AuthRegistry, auth.Engines(), nil
 }
 
@@ -212,8 +217,99 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 				c.metrics.exportAsset.Inc()
 			case *actions.CreateProject:
 				c.metrics.createProject.Inc()
+				if err := storage.StoreOwnerProject(ctx, batch, tx.Auth.Actor(), tx.ID()); err != nil {
+					return err
+				}
+				if err := storage.StoreProjectRevision(ctx, batch, tx.ID(), storage.ProjectRevision{
+					TxID:        tx.ID(),
+					Timestamp:   blk.GetTimestamp(),
+					Description: action.ProjectDescription,
+					Logo:        action.Logo,
+				}); err != nil {
+					return err
+				}
 			case *actions.CreateUpdate:
 				c.metrics.createUpdate.Inc()
+				project, err := actions.ParseProjectID(action.ProjectTxID)
+				if err != nil {
+					// This should never happen
+					return err
+				}
+				if err := storage.StoreProjectUpdate(ctx, batch, project, blk.GetTimestamp(), tx.ID()); err != nil {
+					return err
+				}
+			case *actions.AddMaintainer:
+				c.metrics.addMaintainer.Inc()
+			case *actions.RemoveMaintainer:
+				c.metrics.removeMaintainer.Inc()
+			case *actions.RevokeUpdate:
+				c.metrics.revokeUpdate.Inc()
+			case *actions.ReportUpdateResult:
+				if action.Status == actions.ResultSuccess {
+					c.metrics.updateSuccess.Inc()
+				} else {
+					c.metrics.updateFailure.Inc()
+				}
+			case *actions.RegisterDevice:
+				c.metrics.registerDevice.Inc()
+				if err := storage.StoreProjectDevice(ctx, batch, action.Project, storage.DeviceID(action.PublicKey)); err != nil {
+					return err
+				}
+			case *actions.UpdateDeviceStatus:
+				c.metrics.updateDeviceStatus.Inc()
+			case *actions.DecommissionDevice:
+				c.metrics.decommissionDevice.Inc()
+				c.fleet.Remove(action.Project, action.Device)
+			case *actions.PromoteUpdate:
+				c.metrics.promoteUpdate.Inc()
+			case *actions.SetRolloutPercentage:
+				c.metrics.setRolloutPercentage.Inc()
+			case *actions.ProposeProjectOwner:
+				c.metrics.proposeProjectOwner.Inc()
+			case *actions.AcceptProjectOwner:
+				c.metrics.acceptProjectOwner.Inc()
+				if err := storage.StoreOwnerProject(ctx, batch, tx.Auth.Actor(), action.Project); err != nil {
+					return err
+				}
+			case *actions.EditProject:
+				c.metrics.editProject.Inc()
+				if err := storage.StoreProjectRevision(ctx, batch, action.Project, storage.ProjectRevision{
+					TxID:        tx.ID(),
+					Timestamp:   blk.GetTimestamp(),
+					Description: action.ProjectDescription,
+					Logo:        action.Logo,
+				}); err != nil {
+					return err
+				}
+			case *actions.AddSigningKey:
+				c.metrics.addSigningKey.Inc()
+			case *actions.RemoveSigningKey:
+				c.metrics.removeSigningKey.Inc()
+			case *actions.SetApprovalThreshold:
+				c.metrics.setApprovalThreshold.Inc()
+			case *actions.ApproveUpdate:
+				c.metrics.approveUpdate.Inc()
+			case *actions.SetLicenseAsset:
+				c.metrics.setLicenseAsset.Inc()
+			case *actions.ExportUpdate:
+				c.metrics.exportUpdate.Inc()
+			case *actions.ImportUpdate:
+				c.metrics.importUpdate.Inc()
+				wu, err := actions.UnmarshalWarpUpdate(tx.WarpMessage.Payload)
+				if err != nil {
+					// This should never happen
+					return err
+				}
+				if err := storage.StoreProjectUpdate(ctx, batch, wu.Project, blk.GetTimestamp(), tx.ID()); err != nil {
+					return err
+				}
+			case *actions.AddImportSource:
+				c.metrics.addImportSource.Inc()
+			case *actions.RemoveImportSource:
+				c.metrics.removeImportSource.Inc()
+			case *actions.DeviceHeartbeat:
+				c.metrics.deviceHeartbeat.Inc()
+				c.fleet.Heartbeat(blk.GetTimestamp(), action)
 			}
 		}
 	}
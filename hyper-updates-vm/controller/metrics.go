@@ -27,6 +27,40 @@ type metrics struct {
 
 	createProject prometheus.Counter
 	createUpdate  prometheus.Counter
+
+	addMaintainer    prometheus.Counter
+	removeMaintainer prometheus.Counter
+	revokeUpdate     prometheus.Counter
+	updateSuccess    prometheus.Counter
+	updateFailure    prometheus.Counter
+
+	registerDevice     prometheus.Counter
+	updateDeviceStatus prometheus.Counter
+	decommissionDevice prometheus.Counter
+
+	promoteUpdate prometheus.Counter
+
+	setRolloutPercentage prometheus.Counter
+
+	proposeProjectOwner prometheus.Counter
+	acceptProjectOwner  prometheus.Counter
+
+	editProject prometheus.Counter
+
+	addSigningKey    prometheus.Counter
+	removeSigningKey prometheus.Counter
+
+	setApprovalThreshold prometheus.Counter
+	approveUpdate        prometheus.Counter
+
+	setLicenseAsset prometheus.Counter
+
+	exportUpdate       prometheus.Counter
+	importUpdate       prometheus.Counter
+	addImportSource    prometheus.Counter
+	removeImportSource prometheus.Counter
+
+	deviceHeartbeat prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -86,6 +120,121 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "update",
 			Help:      "no of updates created",
 		}),
+		addMaintainer: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "add_maintainer",
+			Help:      "number of add maintainer actions",
+		}),
+		removeMaintainer: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "remove_maintainer",
+			Help:      "number of remove maintainer actions",
+		}),
+		revokeUpdate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "revoke_update",
+			Help:      "number of revoke update actions",
+		}),
+		updateSuccess: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "update_success",
+			Help:      "number of successful installs reported",
+		}),
+		updateFailure: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "update_failure",
+			Help:      "number of failed or rolled back installs reported",
+		}),
+		registerDevice: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "register_device",
+			Help:      "number of register device actions",
+		}),
+		updateDeviceStatus: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "update_device_status",
+			Help:      "number of update device status actions",
+		}),
+		decommissionDevice: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "decommission_device",
+			Help:      "number of decommission device actions",
+		}),
+		promoteUpdate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "promote_update",
+			Help:      "number of promote update actions",
+		}),
+		setRolloutPercentage: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_rollout_percentage",
+			Help:      "number of set rollout percentage actions",
+		}),
+		proposeProjectOwner: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "propose_project_owner",
+			Help:      "number of propose project owner actions",
+		}),
+		acceptProjectOwner: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "accept_project_owner",
+			Help:      "number of accept project owner actions",
+		}),
+		editProject: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "edit_project",
+			Help:      "number of edit project actions",
+		}),
+		addSigningKey: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "add_signing_key",
+			Help:      "number of add signing key actions",
+		}),
+		removeSigningKey: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "remove_signing_key",
+			Help:      "number of remove signing key actions",
+		}),
+		setApprovalThreshold: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_approval_threshold",
+			Help:      "number of set approval threshold actions",
+		}),
+		approveUpdate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "approve_update",
+			Help:      "number of approve update actions",
+		}),
+		setLicenseAsset: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "set_license_asset",
+			Help:      "number of set license asset actions",
+		}),
+		exportUpdate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "export_update",
+			Help:      "number of export update actions",
+		}),
+		importUpdate: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "import_update",
+			Help:      "number of import update actions",
+		}),
+		addImportSource: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "add_import_source",
+			Help:      "number of add import source actions",
+		}),
+		removeImportSource: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "remove_import_source",
+			Help:      "number of remove import source actions",
+		}),
+		deviceHeartbeat: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "device_heartbeat",
+			Help:      "number of device heartbeat actions",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -104,6 +253,29 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 		r.Register(m.exportAsset),
 		r.Register(m.createProject),
 		r.Register(m.createUpdate),
+		r.Register(m.addMaintainer),
+		r.Register(m.removeMaintainer),
+		r.Register(m.revokeUpdate),
+		r.Register(m.updateSuccess),
+		r.Register(m.updateFailure),
+		r.Register(m.registerDevice),
+		r.Register(m.updateDeviceStatus),
+		r.Register(m.decommissionDevice),
+		r.Register(m.promoteUpdate),
+		r.Register(m.setRolloutPercentage),
+		r.Register(m.proposeProjectOwner),
+		r.Register(m.acceptProjectOwner),
+		r.Register(m.editProject),
+		r.Register(m.addSigningKey),
+		r.Register(m.removeSigningKey),
+		r.Register(m.setApprovalThreshold),
+		r.Register(m.approveUpdate),
+		r.Register(m.setLicenseAsset),
+		r.Register(m.exportUpdate),
+		r.Register(m.importUpdate),
+		r.Register(m.addImportSource),
+		r.Register(m.removeImportSource),
+		r.Register(m.deviceHeartbeat),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
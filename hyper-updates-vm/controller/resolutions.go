@@ -5,7 +5,9 @@ package controller
 
 import (
 	"context"
+	"time"
 
+	"hyper-updates/fleet"
 	"hyper-updates/genesis"
 	"hyper-updates/orderbook"
 	"hyper-updates/storage"
@@ -15,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
 )
 
 func (c *Controller) Genesis() *genesis.Genesis {
@@ -29,6 +32,12 @@ func (c *Controller) Tracer() trace.Tracer {
 	return c.inner.Tracer()
 }
 
+// LastAcceptedTimestamp is the time RPC queries are answered at, in
+// milliseconds.
+func (c *Controller) LastAcceptedTimestamp() int64 {
+	return c.inner.LastAcceptedBlock().Tmstmp
+}
+
 func (c *Controller) GetTransaction(
 	ctx context.Context,
 	txID ids.ID,
@@ -55,6 +64,10 @@ func (c *Controller) Orders(pair string, limit int) []*orderbook.Order {
 	return c.orderBook.Orders(pair, limit)
 }
 
+func (c *Controller) FleetStatus(project ids.ID, staleAfter time.Duration) *fleet.Status {
+	return c.fleet.Status(project, c.LastAcceptedTimestamp(), staleAfter)
+}
+
 func (c *Controller) GetOrderFromState(
 	ctx context.Context,
 	orderID ids.ID,
@@ -87,6 +100,21 @@ func (c *Controller) GetProjectFromState(
 	return storage.GetProjectFromState(ctx, c.inner.ReadState, project)
 }
 
+func (c *Controller) GetProjectHistory(
+	ctx context.Context,
+	project ids.ID,
+	limit int,
+) ([]storage.ProjectRevision, error) {
+	return storage.GetProjectHistory(ctx, c.metaDB, project, limit)
+}
+
+func (c *Controller) GetPendingOwnerFromState(
+	ctx context.Context,
+	project ids.ID,
+) (bool, codec.Address, error) {
+	return storage.GetPendingOwnerFromState(ctx, c.inner.ReadState, project)
+}
+
 func (c *Controller) GetUpdateFromState(
 	ctx context.Context,
 	update ids.ID,
@@ -94,3 +122,123 @@ func (c *Controller) GetUpdateFromState(
 ) (bool, storage.UpdateData, error) {
 	return storage.GetUpdateFromState(ctx, c.inner.ReadState, update)
 }
+
+func (c *Controller) GetLatestUpdateFromState(
+	ctx context.Context,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+) (bool, ids.ID, storage.SemVer, error) {
+	return storage.GetLatestUpdateFromState(ctx, c.inner.ReadState, project, device, channel)
+}
+
+func (c *Controller) GetMaintainersFromState(
+	ctx context.Context,
+	project ids.ID,
+) ([]storage.Maintainer, error) {
+	return storage.GetMaintainersFromState(ctx, c.inner.ReadState, project)
+}
+
+func (c *Controller) GetSigningKeysFromState(
+	ctx context.Context,
+	project ids.ID,
+) ([]ed25519.PublicKey, error) {
+	return storage.GetSigningKeysFromState(ctx, c.inner.ReadState, project)
+}
+
+func (c *Controller) GetImportSourcesFromState(
+	ctx context.Context,
+	project ids.ID,
+) ([]storage.ImportSource, error) {
+	return storage.GetImportSourcesFromState(ctx, c.inner.ReadState, project)
+}
+
+func (c *Controller) GetManifestFromState(
+	ctx context.Context,
+	update ids.ID,
+) (storage.Manifest, error) {
+	return storage.GetManifestFromState(ctx, c.inner.ReadState, update)
+}
+
+func (c *Controller) GetConstraintsFromState(
+	ctx context.Context,
+	update ids.ID,
+) (storage.UpdateConstraints, error) {
+	return storage.GetConstraintsFromState(ctx, c.inner.ReadState, update)
+}
+
+func (c *Controller) GetDeviceFromState(
+	ctx context.Context,
+	device ids.ID,
+) (bool, storage.DeviceData, error) {
+	return storage.GetDeviceFromState(ctx, c.inner.ReadState, device)
+}
+
+func (c *Controller) GetOwnerProjects(
+	ctx context.Context,
+	owner codec.Address,
+	start ids.ID,
+	limit int,
+) ([]ids.ID, ids.ID, error) {
+	return storage.GetOwnerProjects(ctx, c.metaDB, owner, start, limit)
+}
+
+func (c *Controller) GetProjectUpdates(
+	ctx context.Context,
+	project ids.ID,
+	cursor []byte,
+	limit int,
+) ([]storage.ProjectUpdate, []byte, error) {
+	return storage.GetProjectUpdates(ctx, c.metaDB, project, cursor, limit)
+}
+
+func (c *Controller) GetProjectDevices(
+	ctx context.Context,
+	project ids.ID,
+	start ids.ID,
+	limit int,
+) ([]ids.ID, ids.ID, error) {
+	return storage.GetProjectDevices(ctx, c.metaDB, project, start, limit)
+}
+
+func (c *Controller) GetApprovalThresholdFromState(
+	ctx context.Context,
+	project ids.ID,
+) (uint8, error) {
+	return storage.GetApprovalThresholdFromState(ctx, c.inner.ReadState, project)
+}
+
+func (c *Controller) GetApprovalsFromState(
+	ctx context.Context,
+	update ids.ID,
+) (bool, storage.UpdateApprovals, error) {
+	return storage.GetApprovalsFromState(ctx, c.inner.ReadState, update)
+}
+
+func (c *Controller) GetLicenseAssetFromState(
+	ctx context.Context,
+	project ids.ID,
+) (ids.ID, error) {
+	return storage.GetLicenseAssetFromState(ctx, c.inner.ReadState, project)
+}
+
+func (c *Controller) GetUpdateOriginFromState(
+	ctx context.Context,
+	update ids.ID,
+) (storage.UpdateOrigin, error) {
+	return storage.GetUpdateOriginFromState(ctx, c.inner.ReadState, update)
+}
+
+func (c *Controller) GetImportedUpdateFromState(
+	ctx context.Context,
+	origin storage.UpdateOrigin,
+) (bool, ids.ID, error) {
+	return storage.GetImportedUpdateFromState(ctx, c.inner.ReadState, origin)
+}
+
+func (c *Controller) GetUpdateLicenseFromState(
+	ctx context.Context,
+	update ids.ID,
+) (storage.UpdateLicense, error) {
+	return storage.GetUpdateLicenseFromState(ctx, c.inner.ReadState, update)
+}
@@ -0,0 +1,12 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fleet
+
+import (
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type Controller interface {
+	Logger() logging.Logger
+}
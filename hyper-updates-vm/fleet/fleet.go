@@ -0,0 +1,136 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fleet
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"hyper-updates/actions"
+	"hyper-updates/storage"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"go.uber.org/zap"
+)
+
+type Device struct {
+	ID       ids.ID `json:"id"`
+	Update   ids.ID `json:"updateID"`
+	Version  string `json:"version"`
+	Uptime   uint64 `json:"uptime"`
+	LastSeen int64  `json:"lastSeen"` // block timestamp of the last heartbeat
+}
+
+type Status struct {
+	Devices  int            `json:"devices"`
+	Versions map[string]int `json:"versions"` // number of devices per running version
+	Stale    []*Device      `json:"stale"`    // least recently seen first
+}
+
+// Fleet tracks the last heartbeat of every device seen since the node
+// started. Devices that have not sent a heartbeat since then are unknown to
+// it.
+type Fleet struct {
+	c Controller
+
+	// Heartbeats are cheap, cap the number of devices tracked so a spammer
+	// cannot grow the index without bound.
+	projects   map[ids.ID]map[ids.ID]*Device
+	devices    int
+	maxDevices int
+	l          sync.RWMutex
+}
+
+func New(c Controller, maxDevices int) *Fleet {
+	return &Fleet{
+		c:          c,
+		projects:   map[ids.ID]map[ids.ID]*Device{},
+		maxDevices: maxDevices,
+	}
+}
+
+func (f *Fleet) Heartbeat(timestamp int64, action *actions.DeviceHeartbeat) {
+	// Versions are stored in canonical form so the histogram does not split
+	// equal versions
+	version, err := storage.ParseSemVer(string(action.Version))
+	if err != nil {
+		// This should never happen
+		return
+	}
+
+	f.l.Lock()
+	defer f.l.Unlock()
+	devices, ok := f.projects[action.Project]
+	if !ok {
+		devices = map[ids.ID]*Device{}
+		f.projects[action.Project] = devices
+	}
+	device, ok := devices[action.Device]
+	if !ok {
+		if f.devices >= f.maxDevices {
+			f.c.Logger().Debug("dropping heartbeat of untracked device",
+				zap.Stringer("project", action.Project),
+				zap.Stringer("device", action.Device),
+			)
+			if len(devices) == 0 {
+				delete(f.projects, action.Project)
+			}
+			return
+		}
+		device = &Device{ID: action.Device}
+		devices[action.Device] = device
+		f.devices++
+	}
+	device.Update = action.Update
+	device.Version = version.String()
+	device.Uptime = action.Uptime
+	device.LastSeen = timestamp
+}
+
+func (f *Fleet) Remove(project ids.ID, device ids.ID) {
+	f.l.Lock()
+	defer f.l.Unlock()
+
+	devices, ok := f.projects[project]
+	if !ok {
+		return
+	}
+	if _, ok := devices[device]; !ok {
+		return
+	}
+	delete(devices, device)
+	f.devices--
+	if len(devices) == 0 {
+		delete(f.projects, project)
+	}
+}
+
+// Status summarizes the devices of [project] at [now]. Devices whose last
+// heartbeat is older than [staleAfter] are listed as stale.
+func (f *Fleet) Status(project ids.ID, now int64, staleAfter time.Duration) *Status {
+	f.l.RLock()
+	defer f.l.RUnlock()
+
+	// Clients often prefer an empty slice instead of null
+	status := &Status{
+		Versions: map[string]int{},
+		Stale:    []*Device{},
+	}
+	devices := f.projects[project]
+	status.Devices = len(devices)
+	cutoff := now - staleAfter.Milliseconds()
+	for _, device := range devices {
+		status.Versions[device.Version]++
+		if device.LastSeen < cutoff {
+			// Copy so callers never read entries being updated
+			stale := *device
+			status.Stale = append(status.Stale, &stale)
+		}
+	}
+	sort.Slice(status.Stale, func(i, j int) bool {
+		return status.Stale[i].LastSeen < status.Stale[j].LastSeen
+	})
+	return status
+}
@@ -59,6 +59,9 @@ type Genesis struct {
 	StorageKeyWriteUnits      uint64 `json:"storageKeyWriteUnits"`
 	StorageValueWriteUnits    uint64 `json:"storageValueWriteUnits"` // per chunk
 
+	// Warp Parameters
+	WarpSourceChains []ids.ID `json:"warpSourceChains"` // chains allowed to send messages
+
 	// Allocates
 	CustomAllocation []*CustomAllocation `json:"customAllocation"`
 }
@@ -22,12 +22,19 @@ func (g *Genesis) Rules(_ int64, networkID uint32, chainID ids.ID) *Rules {
 	return &Rules{g, networkID, chainID}
 }
 
-func (*Rules) GetWarpConfig(ids.ID) (bool, uint64, uint64) {
-	// We allow inbound transfers from all sources as long as 80% of stake has
-	// signed a message.
+func (r *Rules) GetWarpConfig(sourceChainID ids.ID) (bool, uint64, uint64) {
+	// We only accept messages from the chains listed in genesis, as long as
+	// 80% of their stake has signed them.
 	//
-	// This is safe because the tokenvm scopes all assets by their source chain.
-	return true, 4, 5
+	// Assets are scoped by their source chain, but imported updates land in
+	// projects of this chain. Each project must additionally trust the source
+	// chain and project an update is imported from, see [actions.ImportUpdate].
+	for _, chainID := range r.g.WarpSourceChains {
+		if chainID == sourceChainID {
+			return true, 4, 5
+		}
+	}
+	return false, 0, 0
 }
 
 func (r *Rules) NetworkID() uint32 {
@@ -36,6 +36,28 @@ func init() {
 		consts.ActionRegistry.Register((&actions.ExportAsset{}).GetTypeID(), actions.UnmarshalExportAsset, false),
 		consts.ActionRegistry.Register((&actions.CreateProject{}).GetTypeID(), actions.UnmarshalCreateProject, false),
 		consts.ActionRegistry.Register((&actions.CreateUpdate{}).GetTypeID(), actions.UnmarshalCreateUpdate, false),
+		consts.ActionRegistry.Register((&actions.AddMaintainer{}).GetTypeID(), actions.UnmarshalAddMaintainer, false),
+		consts.ActionRegistry.Register((&actions.RemoveMaintainer{}).GetTypeID(), actions.UnmarshalRemoveMaintainer, false),
+		consts.ActionRegistry.Register((&actions.RevokeUpdate{}).GetTypeID(), actions.UnmarshalRevokeUpdate, false),
+		consts.ActionRegistry.Register((&actions.ReportUpdateResult{}).GetTypeID(), actions.UnmarshalReportUpdateResult, false),
+		consts.ActionRegistry.Register((&actions.RegisterDevice{}).GetTypeID(), actions.UnmarshalRegisterDevice, false),
+		consts.ActionRegistry.Register((&actions.UpdateDeviceStatus{}).GetTypeID(), actions.UnmarshalUpdateDeviceStatus, false),
+		consts.ActionRegistry.Register((&actions.DecommissionDevice{}).GetTypeID(), actions.UnmarshalDecommissionDevice, false),
+		consts.ActionRegistry.Register((&actions.PromoteUpdate{}).GetTypeID(), actions.UnmarshalPromoteUpdate, false),
+		consts.ActionRegistry.Register((&actions.SetRolloutPercentage{}).GetTypeID(), actions.UnmarshalSetRolloutPercentage, false),
+		consts.ActionRegistry.Register((&actions.ProposeProjectOwner{}).GetTypeID(), actions.UnmarshalProposeProjectOwner, false),
+		consts.ActionRegistry.Register((&actions.AcceptProjectOwner{}).GetTypeID(), actions.UnmarshalAcceptProjectOwner, false),
+		consts.ActionRegistry.Register((&actions.EditProject{}).GetTypeID(), actions.UnmarshalEditProject, false),
+		consts.ActionRegistry.Register((&actions.AddSigningKey{}).GetTypeID(), actions.UnmarshalAddSigningKey, false),
+		consts.ActionRegistry.Register((&actions.RemoveSigningKey{}).GetTypeID(), actions.UnmarshalRemoveSigningKey, false),
+		consts.ActionRegistry.Register((&actions.SetApprovalThreshold{}).GetTypeID(), actions.UnmarshalSetApprovalThreshold, false),
+		consts.ActionRegistry.Register((&actions.ApproveUpdate{}).GetTypeID(), actions.UnmarshalApproveUpdate, false),
+		consts.ActionRegistry.Register((&actions.SetLicenseAsset{}).GetTypeID(), actions.UnmarshalSetLicenseAsset, false),
+		consts.ActionRegistry.Register((&actions.ExportUpdate{}).GetTypeID(), actions.UnmarshalExportUpdate, false),
+		consts.ActionRegistry.Register((&actions.ImportUpdate{}).GetTypeID(), actions.UnmarshalImportUpdate, true),
+		consts.ActionRegistry.Register((&actions.AddImportSource{}).GetTypeID(), actions.UnmarshalAddImportSource, false),
+		consts.ActionRegistry.Register((&actions.RemoveImportSource{}).GetTypeID(), actions.UnmarshalRemoveImportSource, false),
+		consts.ActionRegistry.Register((&actions.DeviceHeartbeat{}).GetTypeID(), actions.UnmarshalDeviceHeartbeat, false),
 
 		// When registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register((&auth.ED25519{}).GetTypeID(), auth.UnmarshalED25519, false),
@@ -6,5 +6,17 @@ package rpc
 const (
 	JSONRPCEndpoint = "/tokenapi"
 
-	ordersToSend = 128
+	ordersToSend  = 128
+	devicesToSend = 128
+
+	revisionsToSend = 128
+	projectsToSend  = 128
+	updatesToSend   = 128
+
+	// Devices are reported stale after this many minutes without a heartbeat
+	// unless the caller asks otherwise
+	defaultStaleMinutes = 15
+
+	// Upgrade paths are followed for at most this many pass-through releases
+	maxUpgradePathLen = 16
 )
@@ -5,7 +5,9 @@ package rpc
 
 import (
 	"context"
+	"time"
 
+	"hyper-updates/fleet"
 	"hyper-updates/genesis"
 	"hyper-updates/orderbook"
 	"hyper-updates/storage"
@@ -14,11 +16,13 @@ import (
 	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
 )
 
 type Controller interface {
 	Genesis() *genesis.Genesis
 	Tracer() trace.Tracer
+	LastAcceptedTimestamp() int64
 	GetTransaction(context.Context, ids.ID) (bool, int64, bool, chain.Dimensions, uint64, error)
 	GetAssetFromState(context.Context, ids.ID) (bool, []byte, uint8, []byte, uint64, codec.Address, bool, error)
 	GetBalanceFromState(context.Context, codec.Address, ids.ID) (uint64, error)
@@ -35,5 +39,24 @@ type Controller interface {
 	)
 	GetLoanFromState(context.Context, ids.ID, ids.ID) (uint64, error)
 	GetProjectFromState(context.Context, ids.ID) (bool, storage.ProjectData, error)
+	GetPendingOwnerFromState(context.Context, ids.ID) (bool, codec.Address, error)
+	GetProjectHistory(context.Context, ids.ID, int) ([]storage.ProjectRevision, error)
 	GetUpdateFromState(context.Context, ids.ID) (bool, storage.UpdateData, error)
+	GetLatestUpdateFromState(context.Context, ids.ID, []byte, uint8) (bool, ids.ID, storage.SemVer, error)
+	GetManifestFromState(context.Context, ids.ID) (storage.Manifest, error)
+	GetConstraintsFromState(context.Context, ids.ID) (storage.UpdateConstraints, error)
+	GetMaintainersFromState(context.Context, ids.ID) ([]storage.Maintainer, error)
+	GetSigningKeysFromState(context.Context, ids.ID) ([]ed25519.PublicKey, error)
+	GetImportSourcesFromState(context.Context, ids.ID) ([]storage.ImportSource, error)
+	GetApprovalThresholdFromState(context.Context, ids.ID) (uint8, error)
+	GetApprovalsFromState(context.Context, ids.ID) (bool, storage.UpdateApprovals, error)
+	GetLicenseAssetFromState(context.Context, ids.ID) (ids.ID, error)
+	GetUpdateLicenseFromState(context.Context, ids.ID) (storage.UpdateLicense, error)
+	GetUpdateOriginFromState(context.Context, ids.ID) (storage.UpdateOrigin, error)
+	GetImportedUpdateFromState(context.Context, storage.UpdateOrigin) (bool, ids.ID, error)
+	GetDeviceFromState(context.Context, ids.ID) (bool, storage.DeviceData, error)
+	FleetStatus(project ids.ID, staleAfter time.Duration) *fleet.Status
+	GetProjectDevices(context.Context, ids.ID, ids.ID, int) ([]ids.ID, ids.ID, error)
+	GetOwnerProjects(context.Context, codec.Address, ids.ID, int) ([]ids.ID, ids.ID, error)
+	GetProjectUpdates(context.Context, ids.ID, []byte, int) ([]storage.ProjectUpdate, []byte, error)
 }
@@ -11,4 +11,13 @@ var (
 	ErrOrderNotFound   = errors.New("order not found")
 	ErrProjectNotFound = errors.New("project not found")
 	ErrUpdateNotFound  = errors.New("update not found")
+	ErrNoLatestUpdate  = errors.New("no update released for device")
+	ErrDeviceNotFound  = errors.New("device not found")
+
+	ErrLatestUpdateRevoked = errors.New("every release for device has been revoked")
+
+	ErrUpdateNotYetValid = errors.New("latest update is not valid yet")
+	ErrUpdateExpired     = errors.New("latest update has expired")
+
+	ErrUpgradePathTooLong = errors.New("upgrade path too long")
 )
@@ -12,9 +12,11 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 
 	"hyper-updates/consts"
+	"hyper-updates/fleet"
 	"hyper-updates/genesis"
 	"hyper-updates/orderbook"
 	_ "hyper-updates/registry" // ensure registry populated
+	"hyper-updates/storage"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/requester"
@@ -252,7 +254,7 @@ func (cli *JSONRPCClient) Project(
 	ctx context.Context,
 	project ids.ID,
 	useCache bool,
-) ([]byte, []byte, []byte, []byte, []byte, error) {
+) (*ProjectReply, error) {
 
 	resp := new(ProjectReply)
 	err := cli.requester.SendRequest(
@@ -263,14 +265,34 @@ func (cli *JSONRPCClient) Project(
 		},
 		resp,
 	)
-	return resp.ID, resp.ProjectName, resp.ProjectDescription, resp.ProjectOwner, resp.Logo, err
+	return resp, err
+}
+
+// ProjectHistory returns up to [limit] of the latest metadata revisions of
+// [project], oldest first.
+func (cli *JSONRPCClient) ProjectHistory(
+	ctx context.Context,
+	project ids.ID,
+	limit int,
+) ([]*ProjectRevision, error) {
+	resp := new(ProjectHistoryReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"projectHistory",
+		&ProjectHistoryArgs{
+			Project: project,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.Revisions, err
 }
 
 func (cli *JSONRPCClient) Update(
 	ctx context.Context,
 	update ids.ID,
 	useCache bool,
-) ([]byte, []byte, []byte, []byte, []byte, uint8, uint8, error) {
+) (*UpdateReply, error) {
 
 	resp := new(UpdateReply)
 	err := cli.requester.SendRequest(
@@ -282,5 +304,304 @@ func (cli *JSONRPCClient) Update(
 		resp,
 	)
 
-	return resp.ID, resp.ProjectTxID, resp.UpdateExecutableHash, resp.UpdateIPFSUrl, resp.ForDeviceName, resp.UpdateVersion, resp.SuccessCount, err
+	return resp, err
+}
+
+func (cli *JSONRPCClient) Maintainers(ctx context.Context, project ids.ID) ([]*Maintainer, error) {
+	resp := new(MaintainersReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"maintainers",
+		&MaintainersArgs{
+			Project: project,
+		},
+		resp,
+	)
+	return resp.Maintainers, err
+}
+
+func (cli *JSONRPCClient) SigningKeys(ctx context.Context, project ids.ID) ([]string, error) {
+	resp := new(SigningKeysReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"signingKeys",
+		&SigningKeysArgs{
+			Project: project,
+		},
+		resp,
+	)
+	return resp.SigningKeys, err
+}
+
+func (cli *JSONRPCClient) ImportSources(ctx context.Context, project ids.ID) ([]storage.ImportSource, error) {
+	resp := new(ImportSourcesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"importSources",
+		&ImportSourcesArgs{
+			Project: project,
+		},
+		resp,
+	)
+	return resp.ImportSources, err
+}
+
+func (cli *JSONRPCClient) Approvals(ctx context.Context, update ids.ID) (*ApprovalsReply, error) {
+	resp := new(ApprovalsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"approvals",
+		&ApprovalsArgs{
+			Update: update,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// VerifyUpdateSignature checks the vendor signature of [update] against the
+// signing keys of its project.
+func (cli *JSONRPCClient) VerifyUpdateSignature(
+	ctx context.Context,
+	update ids.ID,
+) (*VerifyUpdateSignatureReply, error) {
+	resp := new(VerifyUpdateSignatureReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"verifyUpdateSignature",
+		&VerifyUpdateSignatureArgs{
+			Update: update,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// ImportedUpdate returns the update [sourceTxID] of [sourceChainID] was
+// imported as, or [ids.Empty] if it was not imported.
+func (cli *JSONRPCClient) ImportedUpdate(
+	ctx context.Context,
+	sourceChainID ids.ID,
+	sourceTxID ids.ID,
+) (ids.ID, error) {
+	resp := new(ImportedUpdateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"importedUpdate",
+		&ImportedUpdateArgs{
+			SourceChainID: sourceChainID,
+			SourceTxID:    sourceTxID,
+		},
+		resp,
+	)
+	return resp.UpdateID, err
+}
+
+// LatestUpdate returns the newest release of [project] for [device] on
+// [channel]. If nothing has been released yet, [ids.Empty] is returned. The
+// release is returned even outside its validity window, callers must check
+// [UpdateReply.Available].
+func (cli *JSONRPCClient) LatestUpdate(
+	ctx context.Context,
+	project ids.ID,
+	device string,
+	channel uint8,
+) (ids.ID, *UpdateReply, error) {
+	resp := new(LatestUpdateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"latestUpdate",
+		&LatestUpdateArgs{
+			Project:            project,
+			Device:             device,
+			Channel:            channel,
+			IncludeUnavailable: true,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), ErrNoLatestUpdate.Error()):
+		return ids.Empty, nil, nil
+	case err != nil && strings.Contains(err.Error(), ErrLatestUpdateRevoked.Error()):
+		return ids.Empty, nil, ErrLatestUpdateRevoked
+	case err != nil:
+		return ids.Empty, nil, err
+	}
+	return resp.UpdateID, resp.Update, nil
+}
+
+func (cli *JSONRPCClient) Device(ctx context.Context, device ids.ID) (*Device, error) {
+	resp := new(DeviceReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"device",
+		&DeviceArgs{
+			Device: device,
+		},
+		resp,
+	)
+	return resp.Device, err
+}
+
+// ProjectDevices returns a page of the devices registered to [project] and
+// the [start] of the next page ([ids.Empty] once all devices were returned).
+func (cli *JSONRPCClient) ProjectDevices(
+	ctx context.Context,
+	project ids.ID,
+	start ids.ID,
+	limit int,
+) ([]*Device, ids.ID, error) {
+	resp := new(ProjectDevicesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"projectDevices",
+		&ProjectDevicesArgs{
+			Project: project,
+			Start:   start,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.Devices, resp.Next, err
+}
+
+// FleetStatus returns the number of devices of [project] the node received
+// heartbeats from, how many run each version and those not seen for
+// [staleMinutes].
+func (cli *JSONRPCClient) FleetStatus(
+	ctx context.Context,
+	project ids.ID,
+	staleMinutes int,
+) (int, map[string]int, []*fleet.Device, error) {
+	resp := new(FleetStatusReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"fleetStatus",
+		&FleetStatusArgs{
+			Project:      project,
+			StaleMinutes: staleMinutes,
+		},
+		resp,
+	)
+	return resp.Devices, resp.Versions, resp.Stale, err
+}
+
+// RolloutEligibility reports whether [device] should receive [update] at its
+// current rollout percentage.
+func (cli *JSONRPCClient) RolloutEligibility(
+	ctx context.Context,
+	update ids.ID,
+	device ids.ID,
+) (*RolloutEligibilityReply, error) {
+	resp := new(RolloutEligibilityReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"rolloutEligibility",
+		&RolloutEligibilityArgs{
+			Update: update,
+			Device: device,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// NextUpdate returns an empty update ID and the reason if no update applies
+// to the device. [address] is only needed for licensed updates.
+func (cli *JSONRPCClient) NextUpdate(
+	ctx context.Context,
+	project ids.ID,
+	device string,
+	channel uint8,
+	model string,
+	hardwareRevision uint16,
+	version string,
+	address string,
+) (*NextUpdateReply, error) {
+	resp := new(NextUpdateReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"nextUpdate",
+		&NextUpdateArgs{
+			Project:          project,
+			Device:           device,
+			Channel:          channel,
+			Model:            model,
+			HardwareRevision: hardwareRevision,
+			Version:          version,
+			Address:          address,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+func (cli *JSONRPCClient) UpdateApplies(
+	ctx context.Context,
+	update ids.ID,
+	model string,
+	hardwareRevision uint16,
+	version string,
+	address string,
+) (*UpdateAppliesReply, error) {
+	resp := new(UpdateAppliesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"updateApplies",
+		&UpdateAppliesArgs{
+			Update:           update,
+			Model:            model,
+			HardwareRevision: hardwareRevision,
+			Version:          version,
+			Address:          address,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+// ListProjects returns a page of the projects [owner] owns and the cursor of
+// the next page, [ids.Empty] on the last page.
+func (cli *JSONRPCClient) ListProjects(
+	ctx context.Context,
+	owner string,
+	cursor ids.ID,
+	limit int,
+) ([]*OwnedProject, ids.ID, error) {
+	resp := new(ListProjectsReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"listProjects",
+		&ListProjectsArgs{
+			Owner:  owner,
+			Cursor: cursor,
+			Limit:  limit,
+		},
+		resp,
+	)
+	return resp.Projects, resp.Next, err
+}
+
+// ListUpdates returns a page of the updates of [project] and the cursor of
+// the next page, empty on the last page.
+func (cli *JSONRPCClient) ListUpdates(
+	ctx context.Context,
+	project ids.ID,
+	cursor string,
+	limit int,
+) ([]*ProjectUpdate, string, error) {
+	resp := new(ListUpdatesReply)
+	err := cli.requester.SendRequest(
+		ctx,
+		"listUpdates",
+		&ListUpdatesArgs{
+			Project: project,
+			Cursor:  cursor,
+			Limit:   limit,
+		},
+		resp,
+	)
+	return resp.Updates, resp.Next, err
 }
@@ -4,13 +4,20 @@
 package rpc
 
 import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 
 	"hyper-updates/consts"
+	"hyper-updates/fleet"
 	"hyper-updates/genesis"
 	"hyper-updates/orderbook"
+	"hyper-updates/storage"
 
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
@@ -196,8 +203,18 @@ type ProjectReply struct {
 	ID                 []byte `json:"ID"`
 	ProjectName        []byte `json:"name"`
 	ProjectDescription []byte `json:"description"`
-	ProjectOwner       []byte `json:"owner"`
+	ProjectOwner       string `json:"owner"`
 	Logo               []byte `json:"logo"`
+
+	// PendingOwner is set while an ownership transfer awaits acceptance.
+	PendingOwner string `json:"pending_owner,omitempty"`
+
+	// ApprovalThreshold is how many approvers must sign off on new updates,
+	// 0 releases them on creation.
+	ApprovalThreshold uint8 `json:"approval_threshold"`
+
+	// LicenseAsset is the asset licenses for premium updates are issued in.
+	LicenseAsset ids.ID `json:"license_asset"`
 }
 
 func (j *JSONRPCServer) Project(req *http.Request, args *ProjectArgs, reply *ProjectReply) error {
@@ -212,13 +229,126 @@ func (j *JSONRPCServer) Project(req *http.Request, args *ProjectArgs, reply *Pro
 	if !exists {
 		return ErrProjectNotFound
 	}
+	fillProjectReply(reply, project)
+
+	exists, pending, err := j.c.GetPendingOwnerFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	if exists {
+		reply.PendingOwner = codec.MustAddressBech32(consts.HRP, pending)
+	}
+	reply.ApprovalThreshold, err = j.c.GetApprovalThresholdFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	reply.LicenseAsset, err = j.c.GetLicenseAssetFromState(ctx, args.Project)
+	return err
+
+}
+
+func fillProjectReply(reply *ProjectReply, project storage.ProjectData) {
 	reply.ID = []byte(project.Key)
 	reply.ProjectName = project.ProjectName
 	reply.ProjectDescription = project.ProjectDescription
-	reply.ProjectOwner = project.ProjectOwner
+	reply.ProjectOwner = codec.MustAddressBech32(consts.HRP, project.ProjectOwner)
 	reply.Logo = project.Logo
-	return err
+}
+
+type ListProjectsArgs struct {
+	Owner string `json:"owner"`
+
+	// Cursor is the first project to return, use [ids.Empty] for the first
+	// page and [ListProjectsReply.Next] for the following ones.
+	Cursor ids.ID `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type OwnedProject struct {
+	ProjectID ids.ID        `json:"project_id"`
+	Project   *ProjectReply `json:"project"`
+}
+
+type ListProjectsReply struct {
+	Projects []*OwnedProject `json:"projects"`
+	Next     ids.ID          `json:"next"` // [ids.Empty] on the last page
+}
+
+// ListProjects returns the projects [Owner] currently owns. Projects it has
+// transferred are left out, so a page may hold fewer than [Limit] projects.
+func (j *JSONRPCServer) ListProjects(req *http.Request, args *ListProjectsArgs, reply *ListProjectsReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ListProjects")
+	defer span.End()
 
+	owner, err := codec.ParseAddressBech32(consts.HRP, args.Owner)
+	if err != nil {
+		return err
+	}
+	limit := args.Limit
+	if limit <= 0 || limit > projectsToSend {
+		limit = projectsToSend
+	}
+	projects, next, err := j.c.GetOwnerProjects(ctx, owner, args.Cursor, limit)
+	if err != nil {
+		return err
+	}
+	reply.Projects = make([]*OwnedProject, 0, len(projects))
+	for _, id := range projects {
+		exists, project, err := j.c.GetProjectFromState(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !exists || project.ProjectOwner != owner {
+			continue
+		}
+		entry := &OwnedProject{ProjectID: id, Project: new(ProjectReply)}
+		fillProjectReply(entry.Project, project)
+		reply.Projects = append(reply.Projects, entry)
+	}
+	reply.Next = next
+	return nil
+}
+
+type ProjectHistoryArgs struct {
+	Project ids.ID `json:"project"`
+	Limit   int    `json:"limit"`
+}
+
+type ProjectRevision struct {
+	TxID        ids.ID `json:"tx_id"`
+	Timestamp   int64  `json:"timestamp"`
+	Description []byte `json:"description"`
+	Logo        []byte `json:"logo"`
+}
+
+type ProjectHistoryReply struct {
+	Revisions []*ProjectRevision `json:"revisions"` // oldest first
+}
+
+// ProjectHistory returns the latest metadata revisions of a project. Only
+// revisions accepted since this node started indexing are known.
+func (j *JSONRPCServer) ProjectHistory(req *http.Request, args *ProjectHistoryArgs, reply *ProjectHistoryReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ProjectHistory")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > revisionsToSend {
+		limit = revisionsToSend
+	}
+	revisions, err := j.c.GetProjectHistory(ctx, args.Project, limit)
+	if err != nil {
+		return err
+	}
+	reply.Revisions = make([]*ProjectRevision, 0, len(revisions))
+	for _, revision := range revisions {
+		reply.Revisions = append(reply.Revisions, &ProjectRevision{
+			TxID:        revision.TxID,
+			Timestamp:   revision.Timestamp,
+			Description: revision.Description,
+			Logo:        revision.Logo,
+		})
+	}
+	return nil
 }
 
 type UpdateArgs struct {
@@ -231,8 +361,53 @@ type UpdateReply struct {
 	UpdateExecutableHash []byte `json:"executable_hash"`
 	UpdateIPFSUrl        []byte `json:"executable_ipfs_url"`
 	ForDeviceName        []byte `json:"for_device_name"`
-	UpdateVersion        uint8  `json:"version"`
-	SuccessCount         uint8  `json:"success_count"`
+	UpdateVersion        string `json:"version"`
+	Channel              uint8  `json:"channel"`
+	SuccessCount         uint64 `json:"success_count"`
+	FailureCount         uint64 `json:"failure_count"`
+	RolloutPercentage    uint8  `json:"rollout_percentage"`
+
+	// Digest is the executable hash as "<algorithm>:<hex>", DigestWeak is
+	// set for legacy MD5 records which should not be trusted.
+	Digest     string `json:"digest"`
+	DigestWeak bool   `json:"digest_weak"`
+	Signature  string `json:"signature,omitempty"` // hex encoded
+
+	// Set when the update also ships as a binary patch against BaseUpdate
+	BaseUpdate   string `json:"base_update,omitempty"`
+	PatchDigest  string `json:"patch_digest,omitempty"`
+	PatchIPFSUrl []byte `json:"patch_ipfs_url,omitempty"`
+
+	// Set for multi-artifact releases, ManifestDigest is what the vendor
+	// signature covers
+	Manifest       []*Artifact `json:"manifest,omitempty"`
+	ManifestDigest string      `json:"manifest_digest,omitempty"`
+
+	// Set when the update is restricted to some hardware or versions
+	Constraints *Constraints `json:"constraints,omitempty"`
+
+	// Validity window in milliseconds, zero bounds are open. Available is
+	// set when the last accepted block falls inside the window, Expired
+	// once the window has ended.
+	NotBefore int64 `json:"not_before,omitempty"`
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	Available bool  `json:"available"`
+	Expired   bool  `json:"expired"`
+
+	// Draft is set while the update awaits approval, drafts are never
+	// served to devices
+	Draft bool `json:"draft"`
+
+	// Set for premium updates, devices must hold the license to install them
+	License *License `json:"license,omitempty"`
+
+	// Set for updates imported from another chain
+	Origin *Origin `json:"origin,omitempty"`
+
+	Revoked      bool   `json:"revoked"`
+	RevokeReason uint8  `json:"revoke_reason"`
+	RevokeNote   string `json:"revoke_note"`
+	RevokedAt    int64  `json:"revoked_at"`
 }
 
 func (j *JSONRPCServer) Update(req *http.Request, args *UpdateArgs, reply *UpdateReply) error {
@@ -249,14 +424,910 @@ func (j *JSONRPCServer) Update(req *http.Request, args *UpdateArgs, reply *Updat
 		return ErrUpdateNotFound
 	}
 
+	fillUpdateReply(reply, update, j.c.LastAcceptedTimestamp())
+	return j.fillReleaseReply(ctx, reply, args.Update)
+
+}
+
+// fillReleaseReply fills the parts of [reply] kept next to the update record.
+func (j *JSONRPCServer) fillReleaseReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	if err := j.fillManifestReply(ctx, reply, update); err != nil {
+		return err
+	}
+	if err := j.fillDraftReply(ctx, reply, update); err != nil {
+		return err
+	}
+	if err := j.fillLicenseReply(ctx, reply, update); err != nil {
+		return err
+	}
+	if err := j.fillOriginReply(ctx, reply, update); err != nil {
+		return err
+	}
+	return j.fillConstraintsReply(ctx, reply, update)
+}
+
+type Artifact struct {
+	Type      string `json:"type"`
+	Partition string `json:"partition,omitempty"`
+	Offset    uint32 `json:"offset"`
+	Size      uint64 `json:"size"`
+	Digest    string `json:"digest"`
+	IPFSUrl   string `json:"ipfs_url"`
+}
+
+func (j *JSONRPCServer) fillManifestReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	manifest, err := j.c.GetManifestFromState(ctx, update)
+	if err != nil || len(manifest) == 0 {
+		return err
+	}
+	for _, a := range manifest {
+		artifact := &Artifact{
+			Type:      a.Type.String(),
+			Partition: string(a.Partition),
+			Offset:    a.Offset,
+			Size:      a.Size,
+			IPFSUrl:   string(a.IPFSUrl),
+		}
+		if digest, err := storage.ParseDigest(a.Digest); err == nil {
+			artifact.Digest = digest.String()
+		}
+		reply.Manifest = append(reply.Manifest, artifact)
+	}
+	reply.ManifestDigest = manifest.Digest().String()
+	return nil
+}
+
+func (j *JSONRPCServer) fillDraftReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	exists, approvals, err := j.c.GetApprovalsFromState(ctx, update)
+	reply.Draft = exists && approvals.Draft()
+	return err
+}
+
+type License struct {
+	Asset      ids.ID `json:"asset"`
+	MinBalance uint64 `json:"min_balance"`
+}
+
+func (j *JSONRPCServer) fillLicenseReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	license, err := j.c.GetUpdateLicenseFromState(ctx, update)
+	if err != nil || license.Empty() {
+		return err
+	}
+	reply.License = &License{Asset: license.Asset, MinBalance: license.MinBalance}
+	return nil
+}
+
+type Origin struct {
+	SourceChainID ids.ID `json:"source_chain_id"`
+	SourceTxID    ids.ID `json:"source_tx_id"`
+	SourceProject ids.ID `json:"source_project_id"`
+}
+
+func (j *JSONRPCServer) fillOriginReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	origin, err := j.c.GetUpdateOriginFromState(ctx, update)
+	if err != nil || origin.Empty() {
+		return err
+	}
+	reply.Origin = &Origin{
+		SourceChainID: origin.SourceChainID,
+		SourceTxID:    origin.SourceTxID,
+		SourceProject: origin.SourceProject,
+	}
+	return nil
+}
+
+// licenseReason returns why [address] may not install [update], or "" if the
+// update needs no license or [address] holds enough of it.
+func (j *JSONRPCServer) licenseReason(ctx context.Context, update ids.ID, address string) (string, error) {
+	license, err := j.c.GetUpdateLicenseFromState(ctx, update)
+	if err != nil || license.Empty() {
+		return "", err
+	}
+	if len(address) == 0 {
+		return "update requires a license, device address not provided", nil
+	}
+	addr, err := codec.ParseAddressBech32(consts.HRP, address)
+	if err != nil {
+		return "", err
+	}
+	balance, err := j.c.GetBalanceFromState(ctx, addr, license.Asset)
+	if err != nil {
+		return "", err
+	}
+	if balance < license.MinBalance {
+		return "device does not hold the update license", nil
+	}
+	return "", nil
+}
+
+type Constraints struct {
+	Boards              []string `json:"boards,omitempty"`
+	MinHardwareRevision uint16   `json:"min_hardware_revision"`
+	MinSourceVersion    string   `json:"min_source_version,omitempty"`
+	MaxSourceVersion    string   `json:"max_source_version,omitempty"`
+	PassThrough         string   `json:"pass_through,omitempty"`
+}
+
+func (j *JSONRPCServer) fillConstraintsReply(ctx context.Context, reply *UpdateReply, update ids.ID) error {
+	constraints, err := j.c.GetConstraintsFromState(ctx, update)
+	if err != nil || constraints.Empty() {
+		return err
+	}
+	reply.Constraints = &Constraints{MinHardwareRevision: constraints.MinHardwareRevision}
+	for _, board := range constraints.Boards {
+		reply.Constraints.Boards = append(reply.Constraints.Boards, string(board))
+	}
+	if constraints.MinSourceVersion != (storage.SemVer{}) {
+		reply.Constraints.MinSourceVersion = constraints.MinSourceVersion.String()
+	}
+	if constraints.MaxSourceVersion != (storage.SemVer{}) {
+		reply.Constraints.MaxSourceVersion = constraints.MaxSourceVersion.String()
+	}
+	if constraints.PassThrough != ids.Empty {
+		reply.Constraints.PassThrough = constraints.PassThrough.String()
+	}
+	return nil
+}
+
+// fillUpdateReply fills [reply] from [update], availability is reported at
+// [timestamp].
+func fillUpdateReply(reply *UpdateReply, update storage.UpdateData, timestamp int64) {
 	reply.ID = []byte(update.Key)
 	reply.ProjectTxID = []byte(update.ProjectTxID)
 	reply.UpdateExecutableHash = []byte(update.UpdateExecutableHash)
 	reply.UpdateIPFSUrl = []byte(update.UpdateIPFSUrl)
 	reply.ForDeviceName = []byte(update.ForDeviceName)
-	reply.UpdateVersion = uint8(update.UpdateVersion)
-	reply.SuccessCount = uint8(update.SuccessCount)
+	reply.UpdateVersion = update.UpdateVersion.String()
+	reply.Channel = update.Channel
+	reply.SuccessCount = update.SuccessCount
+	reply.FailureCount = update.FailureCount
+	reply.RolloutPercentage = update.RolloutPercentage
+	if digest, err := storage.ParseDigest(update.UpdateExecutableHash); err == nil {
+		reply.Digest = digest.String()
+		reply.DigestWeak = digest.Weak()
+	}
+	if len(update.Signature) > 0 {
+		reply.Signature = hex.EncodeToString(update.Signature)
+	}
+	if update.BaseUpdate != ids.Empty {
+		reply.BaseUpdate = update.BaseUpdate.String()
+		if digest, err := storage.ParseDigest(update.PatchHash); err == nil {
+			reply.PatchDigest = digest.String()
+		}
+		reply.PatchIPFSUrl = update.PatchIPFSUrl
+	}
+	reply.NotBefore = update.NotBefore
+	reply.ExpiresAt = update.ExpiresAt
+	window := update.Check(timestamp)
+	reply.Available = window == nil
+	reply.Expired = errors.Is(window, storage.ErrUpdateExpired)
+	reply.Revoked = update.Revoked
+	reply.RevokeReason = update.RevokeReason
+	reply.RevokeNote = string(update.RevokeNote)
+	reply.RevokedAt = update.RevokedAt
+}
+
+type LatestUpdateArgs struct {
+	Project ids.ID `json:"project"`
+	Device  string `json:"device"`
+	Channel uint8  `json:"channel"` // stable if omitted
+
+	// IncludeUnavailable returns the latest update even if it is outside
+	// its validity window, [UpdateReply.Available] tells them apart.
+	IncludeUnavailable bool `json:"include_unavailable"`
+}
+
+type LatestUpdateReply struct {
+	UpdateID ids.ID       `json:"update_id"`
+	Update   *UpdateReply `json:"update"`
+}
+
+func (j *JSONRPCServer) LatestUpdate(req *http.Request, args *LatestUpdateArgs, reply *LatestUpdateReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.LatestUpdate")
+	defer span.End()
+
+	updateID, update, err := j.latestRelease(ctx, args.Project, []byte(args.Device), args.Channel)
+	if err != nil {
+		return err
+	}
+	timestamp := j.c.LastAcceptedTimestamp()
+	if !args.IncludeUnavailable {
+		switch err := update.Check(timestamp); {
+		case errors.Is(err, storage.ErrUpdateNotYetValid):
+			return ErrUpdateNotYetValid
+		case errors.Is(err, storage.ErrUpdateExpired):
+			return ErrUpdateExpired
+		}
+	}
+	reply.UpdateID = updateID
+	reply.Update = new(UpdateReply)
+	fillUpdateReply(reply.Update, update, timestamp)
+	return j.fillReleaseReply(ctx, reply.Update, updateID)
+}
+
+// latestRelease returns the latest update of [channel] that is not revoked.
+// Revoking the latest update leaves the channel pointer on it, so devices are
+// offered the newest earlier release of the channel instead, it is found
+// through the update index of [project].
+func (j *JSONRPCServer) latestRelease(
+	ctx context.Context,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+) (ids.ID, storage.UpdateData, error) {
+	exists, updateID, _, err := j.c.GetLatestUpdateFromState(ctx, project, device, channel)
+	if err != nil {
+		return ids.Empty, storage.UpdateData{}, err
+	}
+	// The latest update of the channel may have been promoted away
+	if !exists || updateID == ids.Empty {
+		return ids.Empty, storage.UpdateData{}, ErrNoLatestUpdate
+	}
+	exists, latest, err := j.c.GetUpdateFromState(ctx, updateID)
+	if err != nil {
+		return ids.Empty, storage.UpdateData{}, err
+	}
+	if !exists {
+		// This should never happen
+		return ids.Empty, storage.UpdateData{}, ErrUpdateNotFound
+	}
+	if !latest.Revoked {
+		return updateID, latest, nil
+	}
+
+	var (
+		releaseID ids.ID
+		release   storage.UpdateData
+		cursor    []byte
+	)
+	for {
+		indexed, next, err := j.c.GetProjectUpdates(ctx, project, cursor, updatesToSend)
+		if err != nil {
+			return ids.Empty, storage.UpdateData{}, err
+		}
+		for _, entry := range indexed {
+			exists, update, err := j.c.GetUpdateFromState(ctx, entry.ID)
+			if err != nil {
+				return ids.Empty, storage.UpdateData{}, err
+			}
+			if !exists || update.Revoked || update.Channel != channel || !bytes.Equal(update.ForDeviceName, device) {
+				continue
+			}
+			if storage.CompareSemVer(update.UpdateVersion, latest.UpdateVersion) >= 0 {
+				continue
+			}
+			if releaseID != ids.Empty && storage.CompareSemVer(update.UpdateVersion, release.UpdateVersion) <= 0 {
+				continue
+			}
+			// Drafts were never released to the channel
+			exists, approvals, err := j.c.GetApprovalsFromState(ctx, entry.ID)
+			if err != nil {
+				return ids.Empty, storage.UpdateData{}, err
+			}
+			if exists && approvals.Draft() {
+				continue
+			}
+			releaseID, release = entry.ID, update
+		}
+		if len(next) == 0 {
+			break
+		}
+		cursor = next
+	}
+	if releaseID == ids.Empty {
+		return ids.Empty, storage.UpdateData{}, ErrLatestUpdateRevoked
+	}
+	return releaseID, release, nil
+}
+
+type ListUpdatesArgs struct {
+	Project ids.ID `json:"project"`
+
+	// Cursor is where the page starts, leave it empty for the first page and
+	// use [ListUpdatesReply.Next] for the following ones.
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+type ProjectUpdate struct {
+	UpdateID  ids.ID       `json:"update_id"`
+	Timestamp int64        `json:"timestamp"`
+	Update    *UpdateReply `json:"update"`
+}
+
+type ListUpdatesReply struct {
+	Updates []*ProjectUpdate `json:"updates"`
+	Next    string           `json:"next"` // empty on the last page
+}
+
+// ListUpdates returns the updates of [Project] in release order.
+func (j *JSONRPCServer) ListUpdates(req *http.Request, args *ListUpdatesArgs, reply *ListUpdatesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ListUpdates")
+	defer span.End()
+
+	cursor, err := hex.DecodeString(args.Cursor)
+	if err != nil {
+		return err
+	}
+	limit := args.Limit
+	if limit <= 0 || limit > updatesToSend {
+		limit = updatesToSend
+	}
+	updates, next, err := j.c.GetProjectUpdates(ctx, args.Project, cursor, limit)
+	if err != nil {
+		return err
+	}
+	timestamp := j.c.LastAcceptedTimestamp()
+	reply.Updates = make([]*ProjectUpdate, 0, len(updates))
+	for _, indexed := range updates {
+		exists, update, err := j.c.GetUpdateFromState(ctx, indexed.ID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Indexed on accept, so this should never happen
+			continue
+		}
+		entry := &ProjectUpdate{
+			UpdateID:  indexed.ID,
+			Timestamp: indexed.Timestamp,
+			Update:    new(UpdateReply),
+		}
+		fillUpdateReply(entry.Update, update, timestamp)
+		if err := j.fillDraftReply(ctx, entry.Update, indexed.ID); err != nil {
+			return err
+		}
+		reply.Updates = append(reply.Updates, entry)
+	}
+	reply.Next = hex.EncodeToString(next)
+	return nil
+}
+
+type NextUpdateArgs struct {
+	Project          ids.ID `json:"project"`
+	Device           string `json:"device"`
+	Channel          uint8  `json:"channel"` // stable if omitted
+	Model            string `json:"model"`
+	HardwareRevision uint16 `json:"hardware_revision"`
+	Version          string `json:"version"` // version the device runs
+	Address          string `json:"address"` // device address, required for licensed updates
+}
+
+type NextUpdateReply struct {
+	UpdateID ids.ID       `json:"update_id"` // empty if no update applies
+	Update   *UpdateReply `json:"update,omitempty"`
+	Reason   string       `json:"reason,omitempty"` // why no update applies
+}
+
+// NextUpdate returns the update a device should install next to reach the
+// latest release of its channel. Pass-through releases the device has not
+// installed yet come first.
+func (j *JSONRPCServer) NextUpdate(req *http.Request, args *NextUpdateArgs, reply *NextUpdateReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.NextUpdate")
+	defer span.End()
+
+	version, err := storage.ParseSemVer(args.Version)
+	if err != nil {
+		return err
+	}
+	updateID, _, err := j.latestRelease(ctx, args.Project, []byte(args.Device), args.Channel)
+	if errors.Is(err, ErrLatestUpdateRevoked) {
+		reply.Reason = err.Error()
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	timestamp := j.c.LastAcceptedTimestamp()
+	for i := 0; i < maxUpgradePathLen; i++ {
+		exists, update, err := j.c.GetUpdateFromState(ctx, updateID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUpdateNotFound
+		}
+		if storage.CompareSemVer(version, update.UpdateVersion) >= 0 {
+			reply.Reason = "device is up to date"
+			return nil
+		}
+		constraints, err := j.c.GetConstraintsFromState(ctx, updateID)
+		if err != nil {
+			return err
+		}
+		if pass := constraints.PassThrough; pass != ids.Empty {
+			exists, passUpdate, err := j.c.GetUpdateFromState(ctx, pass)
+			if err != nil {
+				return err
+			}
+			if exists && storage.CompareSemVer(version, passUpdate.UpdateVersion) < 0 {
+				updateID = pass
+				continue
+			}
+		}
+		if update.Revoked {
+			reply.Reason = "update " + updateID.String() + " in the upgrade path has been revoked"
+			return nil
+		}
+		if err := update.Check(timestamp); err != nil {
+			reply.Reason = "update " + updateID.String() + " in the upgrade path: " + err.Error()
+			return nil
+		}
+		if err := constraints.Check([]byte(args.Model), args.HardwareRevision, version); err != nil {
+			reply.Reason = err.Error()
+			return nil
+		}
+		reason, err := j.licenseReason(ctx, updateID, args.Address)
+		if err != nil {
+			return err
+		}
+		if len(reason) > 0 {
+			reply.Reason = reason
+			return nil
+		}
+		reply.UpdateID = updateID
+		reply.Update = new(UpdateReply)
+		fillUpdateReply(reply.Update, update, timestamp)
+		return j.fillReleaseReply(ctx, reply.Update, updateID)
+	}
+	return ErrUpgradePathTooLong
+}
+
+type UpdateAppliesArgs struct {
+	Update           ids.ID `json:"update"`
+	Model            string `json:"model"`
+	HardwareRevision uint16 `json:"hardware_revision"`
+	Version          string `json:"version"` // version the device runs
+	Address          string `json:"address"` // device address, required for licensed updates
+}
+
+type UpdateAppliesReply struct {
+	Applies bool   `json:"applies"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// UpdateApplies reports whether [Update] can be installed on a device, it
+// does not look for a newer release.
+func (j *JSONRPCServer) UpdateApplies(req *http.Request, args *UpdateAppliesArgs, reply *UpdateAppliesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.UpdateApplies")
+	defer span.End()
+
+	version, err := storage.ParseSemVer(args.Version)
+	if err != nil {
+		return err
+	}
+	exists, update, err := j.c.GetUpdateFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUpdateNotFound
+	}
+	if update.Revoked {
+		reply.Reason = "update has been revoked"
+		return nil
+	}
+	if err := update.Check(j.c.LastAcceptedTimestamp()); err != nil {
+		reply.Reason = err.Error()
+		return nil
+	}
+	exists, approvals, err := j.c.GetApprovalsFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if exists && approvals.Draft() {
+		reply.Reason = "update is a draft awaiting approval"
+		return nil
+	}
+	constraints, err := j.c.GetConstraintsFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if err := constraints.Check([]byte(args.Model), args.HardwareRevision, version); err != nil {
+		reply.Reason = err.Error()
+		return nil
+	}
+	if pass := constraints.PassThrough; pass != ids.Empty {
+		exists, passUpdate, err := j.c.GetUpdateFromState(ctx, pass)
+		if err != nil {
+			return err
+		}
+		if exists && storage.CompareSemVer(version, passUpdate.UpdateVersion) < 0 {
+			reply.Reason = "device must first install " + pass.String()
+			return nil
+		}
+	}
+	reply.Reason, err = j.licenseReason(ctx, args.Update, args.Address)
+	if err != nil {
+		return err
+	}
+	reply.Applies = len(reply.Reason) == 0
+	return nil
+}
+
+type RolloutEligibilityArgs struct {
+	Update ids.ID `json:"update"`
+	Device ids.ID `json:"device"`
+}
 
+type RolloutEligibilityReply struct {
+	Eligible   bool  `json:"eligible"`
+	Bucket     uint8 `json:"bucket"`
+	Percentage uint8 `json:"percentage"`
+}
+
+// RolloutEligibility reports whether [Device] falls inside the current rollout
+// of [Update]. Revoked updates, drafts and updates outside their validity
+// window are never eligible.
+func (j *JSONRPCServer) RolloutEligibility(req *http.Request, args *RolloutEligibilityArgs, reply *RolloutEligibilityReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.RolloutEligibility")
+	defer span.End()
+
+	exists, update, err := j.c.GetUpdateFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUpdateNotFound
+	}
+	exists, approvals, err := j.c.GetApprovalsFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	reply.Bucket = storage.RolloutBucket(args.Device, args.Update)
+	reply.Percentage = update.RolloutPercentage
+	reply.Eligible = !update.Revoked &&
+		!(exists && approvals.Draft()) &&
+		update.Check(j.c.LastAcceptedTimestamp()) == nil &&
+		reply.Bucket < reply.Percentage
+	return nil
+}
+
+type ImportedUpdateArgs struct {
+	SourceChainID ids.ID `json:"source_chain_id"`
+	SourceTxID    ids.ID `json:"source_tx_id"`
+}
+
+type ImportedUpdateReply struct {
+	Imported bool   `json:"imported"`
+	UpdateID ids.ID `json:"update_id"`
+}
+
+// ImportedUpdate returns the update [SourceTxID] of [SourceChainID] was
+// imported as on this chain.
+func (j *JSONRPCServer) ImportedUpdate(req *http.Request, args *ImportedUpdateArgs, reply *ImportedUpdateReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ImportedUpdate")
+	defer span.End()
+
+	// The source project is not part of the index
+	var err error
+	reply.Imported, reply.UpdateID, err = j.c.GetImportedUpdateFromState(ctx, storage.UpdateOrigin{
+		SourceChainID: args.SourceChainID,
+		SourceTxID:    args.SourceTxID,
+	})
 	return err
+}
+
+type VerifyUpdateSignatureArgs struct {
+	Update ids.ID `json:"update"`
+}
+
+type VerifyUpdateSignatureReply struct {
+	Signed     bool   `json:"signed"`
+	Valid      bool   `json:"valid"`
+	SigningKey string `json:"signing_key,omitempty"` // hex encoded
+}
+
+// VerifyUpdateSignature checks the vendor signature of [Update] against the
+// signing keys its project currently has registered, so a signature made with
+// a key that has since been removed is no longer valid.
+func (j *JSONRPCServer) VerifyUpdateSignature(req *http.Request, args *VerifyUpdateSignatureArgs, reply *VerifyUpdateSignatureReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.VerifyUpdateSignature")
+	defer span.End()
+
+	exists, update, err := j.c.GetUpdateFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUpdateNotFound
+	}
+	reply.Signed = len(update.Signature) > 0
+	if !reply.Signed {
+		return nil
+	}
+	project, err := ids.FromString(string(update.ProjectTxID))
+	if err != nil {
+		return err
+	}
+	keys, err := j.c.GetSigningKeysFromState(ctx, project)
+	if err != nil {
+		return err
+	}
+	// Imported updates were signed for the project they were published in
+	origin, err := j.c.GetUpdateOriginFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if !origin.Empty() {
+		project = origin.SourceProject
+	}
+	// Multi-artifact releases are signed over their manifest
+	digest := update.UpdateExecutableHash
+	manifest, err := j.c.GetManifestFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if len(manifest) > 0 {
+		digest = manifest.Digest().Bytes()
+	}
+	msg := storage.UpdateSigningMessage(project, update.UpdateVersion, update.ForDeviceName, digest)
+	key, ok := storage.VerifyUpdateSignature(keys, msg, update.Signature)
+	if ok {
+		reply.Valid = true
+		reply.SigningKey = hex.EncodeToString(key[:])
+	}
+	return nil
+}
+
+type MaintainersArgs struct {
+	Project ids.ID `json:"project"`
+}
+
+type Maintainer struct {
+	Address string `json:"address"` // we always send address over RPC
+	Roles   uint8  `json:"roles"`
+}
+
+type MaintainersReply struct {
+	Maintainers []*Maintainer `json:"maintainers"`
+}
+
+func (j *JSONRPCServer) Maintainers(req *http.Request, args *MaintainersArgs, reply *MaintainersReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Maintainers")
+	defer span.End()
+
+	exists, _, err := j.c.GetProjectFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+	maintainers, err := j.c.GetMaintainersFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	reply.Maintainers = make([]*Maintainer, len(maintainers))
+	for i, m := range maintainers {
+		reply.Maintainers[i] = &Maintainer{
+			Address: codec.MustAddressBech32(consts.HRP, m.Address),
+			Roles:   m.Roles,
+		}
+	}
+	return nil
+}
+
+type ApprovalsArgs struct {
+	Update ids.ID `json:"update"`
+}
 
+type Approval struct {
+	Approver  string `json:"approver"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type ApprovalsReply struct {
+	Threshold  uint8       `json:"threshold"` // 0 if the update never needed approval
+	Author     string      `json:"author,omitempty"`
+	Approvals  []*Approval `json:"approvals"`
+	Draft      bool        `json:"draft"`
+	ReleasedAt int64       `json:"released_at,omitempty"`
+}
+
+// Approvals returns the sign-offs collected by [Update], in approval order.
+func (j *JSONRPCServer) Approvals(req *http.Request, args *ApprovalsArgs, reply *ApprovalsReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Approvals")
+	defer span.End()
+
+	exists, _, err := j.c.GetUpdateFromState(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrUpdateNotFound
+	}
+	exists, approvals, err := j.c.GetApprovalsFromState(ctx, args.Update)
+	if err != nil || !exists {
+		return err
+	}
+	reply.Threshold = approvals.Threshold
+	reply.Author = codec.MustAddressBech32(consts.HRP, approvals.Author)
+	reply.Approvals = make([]*Approval, len(approvals.Approvals))
+	for i, approval := range approvals.Approvals {
+		reply.Approvals[i] = &Approval{
+			Approver:  codec.MustAddressBech32(consts.HRP, approval.Approver),
+			Timestamp: approval.Timestamp,
+		}
+	}
+	reply.Draft = approvals.Draft()
+	reply.ReleasedAt = approvals.ReleasedAt
+	return nil
+}
+
+type SigningKeysArgs struct {
+	Project ids.ID `json:"project"`
+}
+
+type SigningKeysReply struct {
+	SigningKeys []string `json:"signing_keys"` // hex encoded
+}
+
+func (j *JSONRPCServer) SigningKeys(req *http.Request, args *SigningKeysArgs, reply *SigningKeysReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.SigningKeys")
+	defer span.End()
+
+	exists, _, err := j.c.GetProjectFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+	keys, err := j.c.GetSigningKeysFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	reply.SigningKeys = make([]string, len(keys))
+	for i, key := range keys {
+		reply.SigningKeys[i] = hex.EncodeToString(key[:])
+	}
+	return nil
+}
+
+type ImportSourcesArgs struct {
+	Project ids.ID `json:"project"`
+}
+
+type ImportSourcesReply struct {
+	ImportSources []storage.ImportSource `json:"import_sources"`
+}
+
+func (j *JSONRPCServer) ImportSources(req *http.Request, args *ImportSourcesArgs, reply *ImportSourcesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ImportSources")
+	defer span.End()
+
+	exists, _, err := j.c.GetProjectFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrProjectNotFound
+	}
+	sources, err := j.c.GetImportSourcesFromState(ctx, args.Project)
+	if err != nil {
+		return err
+	}
+	reply.ImportSources = sources
+	return nil
+}
+
+type DeviceArgs struct {
+	Device ids.ID `json:"device"`
+}
+
+type Device struct {
+	ID           ids.ID `json:"id"`
+	Project      ids.ID `json:"project_id"`
+	PublicKey    string `json:"public_key"` // hex encoded
+	Status       uint8  `json:"status"`
+	Model        string `json:"model"`
+	Version      string `json:"version"`
+	RegisteredAt int64  `json:"registered_at"`
+	UpdatedAt    int64  `json:"updated_at"`
+}
+
+type DeviceReply struct {
+	Device *Device `json:"device"`
+}
+
+func newDevice(data storage.DeviceData) *Device {
+	return &Device{
+		ID:           data.ID,
+		Project:      data.Project,
+		PublicKey:    hex.EncodeToString(data.PublicKey[:]),
+		Status:       data.Status,
+		Model:        string(data.Model),
+		Version:      data.Version.String(),
+		RegisteredAt: data.RegisteredAt,
+		UpdatedAt:    data.UpdatedAt,
+	}
+}
+
+func (j *JSONRPCServer) Device(req *http.Request, args *DeviceArgs, reply *DeviceReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.Device")
+	defer span.End()
+
+	exists, data, err := j.c.GetDeviceFromState(ctx, args.Device)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrDeviceNotFound
+	}
+	reply.Device = newDevice(data)
+	return nil
+}
+
+type ProjectDevicesArgs struct {
+	Project ids.ID `json:"project"`
+
+	// Start is the first device to return, use [ids.Empty] for the first page
+	// and [ProjectDevicesReply.Next] for the following ones.
+	Start ids.ID `json:"start"`
+	Limit int    `json:"limit"`
+}
+
+type ProjectDevicesReply struct {
+	Devices []*Device `json:"devices"`
+	Next    ids.ID    `json:"next"` // [ids.Empty] on the last page
+}
+
+func (j *JSONRPCServer) ProjectDevices(req *http.Request, args *ProjectDevicesArgs, reply *ProjectDevicesReply) error {
+	ctx, span := j.c.Tracer().Start(req.Context(), "Server.ProjectDevices")
+	defer span.End()
+
+	limit := args.Limit
+	if limit <= 0 || limit > devicesToSend {
+		limit = devicesToSend
+	}
+	devices, next, err := j.c.GetProjectDevices(ctx, args.Project, args.Start, limit)
+	if err != nil {
+		return err
+	}
+	reply.Devices = make([]*Device, 0, len(devices))
+	for _, device := range devices {
+		exists, data, err := j.c.GetDeviceFromState(ctx, device)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// Indexed on accept, so this should never happen
+			continue
+		}
+		reply.Devices = append(reply.Devices, newDevice(data))
+	}
+	reply.Next = next
+	return nil
+}
+
+type FleetStatusArgs struct {
+	Project ids.ID `json:"project"`
+
+	// StaleMinutes is how long a device may go without a heartbeat before it
+	// is reported, 15 if not set.
+	StaleMinutes int `json:"staleMinutes"`
+}
+
+type FleetStatusReply struct {
+	Devices  int             `json:"devices"`
+	Versions map[string]int  `json:"versions"`
+	Stale    []*fleet.Device `json:"stale"`
+}
+
+// FleetStatus reports the versions the devices of a project run, from the
+// heartbeats this node accepted since it started.
+func (j *JSONRPCServer) FleetStatus(req *http.Request, args *FleetStatusArgs, reply *FleetStatusReply) error {
+	_, span := j.c.Tracer().Start(req.Context(), "Server.FleetStatus")
+	defer span.End()
+
+	minutes := args.StaleMinutes
+	if minutes <= 0 {
+		minutes = defaultStaleMinutes
+	}
+	status := j.c.FleetStatus(args.Project, time.Duration(minutes)*time.Minute)
+	reply.Devices = status.Devices
+	reply.Versions = status.Versions
+	reply.Stale = status.Stale
+	return nil
 }
@@ -0,0 +1,177 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	// MaxConstraintBoards bounds the boards of an update so its constraints
+	// always fit in [ConstraintsChunks].
+	MaxConstraintBoards = 8
+
+	maxConstraintsLen = consts.IntLen + MaxConstraintBoards*(consts.IntLen+MaxDeviceModelLen) +
+		consts.IntLen +
+		2*(consts.IntLen+MaxSemVerLen) +
+		consts.IDLen
+)
+
+// UpdateConstraints restrict which devices an update can be installed on. The
+// zero value of every field places no restriction.
+type UpdateConstraints struct {
+	// Boards are the device models (see [DeviceData.Model]) the build runs on
+	Boards [][]byte `json:"boards"`
+
+	MinHardwareRevision uint16 `json:"min_hardware_revision"`
+
+	// Devices must run a version in [MinSourceVersion, MaxSourceVersion] to
+	// install the update
+	MinSourceVersion SemVer `json:"min_source_version"`
+	MaxSourceVersion SemVer `json:"max_source_version"`
+
+	// PassThrough is a release devices on older versions must install
+	// before the update, like a migration that can't be skipped
+	PassThrough ids.ID `json:"pass_through"`
+}
+
+func (c UpdateConstraints) Empty() bool {
+	return len(c.Boards) == 0 &&
+		c.MinHardwareRevision == 0 &&
+		c.MinSourceVersion == (SemVer{}) &&
+		c.MaxSourceVersion == (SemVer{}) &&
+		c.PassThrough == ids.Empty
+}
+
+// Validate checks that the constraints are well formed, the pass-through
+// release is checked by the caller.
+func (c UpdateConstraints) Validate() error {
+	if len(c.Boards) > MaxConstraintBoards {
+		return ErrTooManyBoards
+	}
+	for _, board := range c.Boards {
+		if len(board) == 0 || len(board) > MaxDeviceModelLen {
+			return ErrInvalidConstraints
+		}
+	}
+	for _, v := range []SemVer{c.MinSourceVersion, c.MaxSourceVersion} {
+		if v == (SemVer{}) {
+			continue
+		}
+		if parsed, err := ParseSemVer(v.String()); err != nil || parsed != v {
+			return ErrInvalidSemVer
+		}
+	}
+	if c.MaxSourceVersion != (SemVer{}) && CompareSemVer(c.MinSourceVersion, c.MaxSourceVersion) > 0 {
+		return ErrInvalidConstraints
+	}
+	return nil
+}
+
+// Check returns why a device of [model] and [hardwareRevision] running
+// [version] can't install the update, or nil if it can.
+func (c UpdateConstraints) Check(model []byte, hardwareRevision uint16, version SemVer) error {
+	if len(c.Boards) > 0 {
+		allowed := false
+		for _, board := range c.Boards {
+			if bytes.Equal(board, model) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrBoardNotAllowed
+		}
+	}
+	if hardwareRevision < c.MinHardwareRevision {
+		return ErrHardwareRevisionTooLow
+	}
+	if CompareSemVer(version, c.MinSourceVersion) < 0 {
+		return ErrSourceVersionTooLow
+	}
+	if c.MaxSourceVersion != (SemVer{}) && CompareSemVer(version, c.MaxSourceVersion) > 0 {
+		return ErrSourceVersionTooHigh
+	}
+	return nil
+}
+
+// Size returns the number of bytes [PackConstraints] writes for the
+// constraints.
+func (c UpdateConstraints) Size() int {
+	size := consts.IntLen
+	for _, board := range c.Boards {
+		size += codec.BytesLen(board)
+	}
+	return size +
+		consts.IntLen +
+		consts.IntLen + packedSemVerLen(c.MinSourceVersion) +
+		consts.IntLen + packedSemVerLen(c.MaxSourceVersion) +
+		consts.IDLen
+}
+
+func PackConstraints(p *codec.Packer, c UpdateConstraints) {
+	p.PackInt(len(c.Boards))
+	for _, board := range c.Boards {
+		p.PackBytes(board)
+	}
+	p.PackInt(int(c.MinHardwareRevision))
+	packSemVer(p, c.MinSourceVersion)
+	packSemVer(p, c.MaxSourceVersion)
+	p.PackID(c.PassThrough)
+}
+
+// UnpackConstraints reads constraints written by [PackConstraints]. Contents
+// are not validated, see [UpdateConstraints.Validate].
+func UnpackConstraints(p *codec.Packer) (UpdateConstraints, error) {
+	var c UpdateConstraints
+	count := p.UnpackInt(false)
+	if count > MaxConstraintBoards {
+		return UpdateConstraints{}, ErrTooManyBoards
+	}
+	if count > 0 {
+		c.Boards = make([][]byte, count)
+	}
+	for i := range c.Boards {
+		p.UnpackBytes(MaxDeviceModelLen, true, &c.Boards[i])
+	}
+	c.MinHardwareRevision = uint16(p.UnpackInt(false))
+	var err error
+	if c.MinSourceVersion, err = unpackSemVer(p); err != nil {
+		return UpdateConstraints{}, err
+	}
+	if c.MaxSourceVersion, err = unpackSemVer(p); err != nil {
+		return UpdateConstraints{}, err
+	}
+	p.UnpackID(false, &c.PassThrough)
+	return c, p.Err()
+}
+
+// Unset versions are packed empty
+func packedSemVerLen(v SemVer) int {
+	if v == (SemVer{}) {
+		return 0
+	}
+	return semVerLen(v)
+}
+
+func packSemVer(p *codec.Packer, v SemVer) {
+	b := make([]byte, packedSemVerLen(v))
+	if len(b) > 0 {
+		encodeSemVer(b, v)
+	}
+	p.PackBytes(b)
+}
+
+func unpackSemVer(p *codec.Packer) (SemVer, error) {
+	var b []byte
+	p.UnpackBytes(MaxSemVerLen, false, &b)
+	if len(b) == 0 {
+		return SemVer{}, p.Err()
+	}
+	return decodeSemVer(b)
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func mustParseSemVer(s string) SemVer {
+	v, err := ParseSemVer(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestConstraintsCheck(t *testing.T) {
+	c := UpdateConstraints{
+		Boards:              [][]byte{[]byte("esp32-s3"), []byte("esp32-c3")},
+		MinHardwareRevision: 2,
+		MinSourceVersion:    mustParseSemVer("1.2.0"),
+		MaxSourceVersion:    mustParseSemVer("1.9.0"),
+	}
+	tests := []struct {
+		name     string
+		c        UpdateConstraints
+		model    string
+		revision uint16
+		version  string
+		err      error
+	}{
+		{name: "unconstrained", c: UpdateConstraints{}, model: "esp8266", version: "0.0.1"},
+		{name: "first board", c: c, model: "esp32-s3", revision: 2, version: "1.5.0"},
+		{name: "second board", c: c, model: "esp32-c3", revision: 7, version: "1.5.0"},
+		{name: "other board", c: c, model: "esp8266", revision: 2, version: "1.5.0", err: ErrBoardNotAllowed},
+		{name: "board prefix", c: c, model: "esp32", revision: 2, version: "1.5.0", err: ErrBoardNotAllowed},
+		{name: "board case", c: c, model: "ESP32-S3", revision: 2, version: "1.5.0", err: ErrBoardNotAllowed},
+		{name: "old revision", c: c, model: "esp32-s3", revision: 1, version: "1.5.0", err: ErrHardwareRevisionTooLow},
+		{name: "min version", c: c, model: "esp32-s3", revision: 2, version: "1.2.0"},
+		{name: "max version", c: c, model: "esp32-s3", revision: 2, version: "1.9.0"},
+		{name: "below min", c: c, model: "esp32-s3", revision: 2, version: "1.1.9", err: ErrSourceVersionTooLow},
+		{name: "pre-release of min", c: c, model: "esp32-s3", revision: 2, version: "1.2.0-rc.1", err: ErrSourceVersionTooLow},
+		{name: "build of max", c: c, model: "esp32-s3", revision: 2, version: "1.9.0+linux"},
+		{name: "above max", c: c, model: "esp32-s3", revision: 2, version: "1.9.1", err: ErrSourceVersionTooHigh},
+		{
+			name:    "no max",
+			c:       UpdateConstraints{MinSourceVersion: mustParseSemVer("1.0.0")},
+			model:   "esp32-s3",
+			version: "99.0.0",
+		},
+	}
+	for _, tt := range tests {
+		err := tt.c.Check([]byte(tt.model), tt.revision, mustParseSemVer(tt.version))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: Check(%s, %d, %s) = %v, want %v", tt.name, tt.model, tt.revision, tt.version, err, tt.err)
+		}
+	}
+}
+
+func TestConstraintsValidate(t *testing.T) {
+	tooMany := make([][]byte, MaxConstraintBoards+1)
+	for i := range tooMany {
+		tooMany[i] = []byte("esp32")
+	}
+	tests := []struct {
+		name string
+		c    UpdateConstraints
+		err  error
+	}{
+		{name: "empty", c: UpdateConstraints{}},
+		{name: "boards", c: UpdateConstraints{Boards: [][]byte{[]byte("esp32"), bytes.Repeat([]byte("b"), MaxDeviceModelLen)}}},
+		{name: "range", c: UpdateConstraints{MinSourceVersion: mustParseSemVer("1.0.0"), MaxSourceVersion: mustParseSemVer("1.0.0")}},
+		{name: "only max", c: UpdateConstraints{MaxSourceVersion: mustParseSemVer("2.0.0")}},
+		{name: "too many boards", c: UpdateConstraints{Boards: tooMany}, err: ErrTooManyBoards},
+		{name: "empty board", c: UpdateConstraints{Boards: [][]byte{{}}}, err: ErrInvalidConstraints},
+		{name: "long board", c: UpdateConstraints{Boards: [][]byte{bytes.Repeat([]byte("b"), MaxDeviceModelLen+1)}}, err: ErrInvalidConstraints},
+		{
+			name: "inverted range",
+			c:    UpdateConstraints{MinSourceVersion: mustParseSemVer("2.0.0"), MaxSourceVersion: mustParseSemVer("1.9.9")},
+			err:  ErrInvalidConstraints,
+		},
+		{name: "invalid version", c: UpdateConstraints{MinSourceVersion: SemVer{Major: 1, PreRelease: "rc..1"}}, err: ErrInvalidSemVer},
+	}
+	for _, tt := range tests {
+		if err := tt.c.Validate(); !errors.Is(err, tt.err) {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestConstraintsEncoding(t *testing.T) {
+	for _, c := range []UpdateConstraints{
+		{},
+		{
+			Boards:              [][]byte{[]byte("esp32-s3")},
+			MinHardwareRevision: 3,
+			MinSourceVersion:    mustParseSemVer("1.0.0-rc.1"),
+			MaxSourceVersion:    mustParseSemVer("2.0.0"),
+			PassThrough:         ids.GenerateTestID(),
+		},
+	} {
+		p := codec.NewWriter(c.Size(), c.Size())
+		PackConstraints(p, c)
+		if err := p.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Bytes()) != c.Size() {
+			t.Fatalf("packed %d bytes, Size() = %d", len(p.Bytes()), c.Size())
+		}
+		got, err := UnpackConstraints(codec.NewReader(p.Bytes(), c.Size()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Empty() != c.Empty() ||
+			len(got.Boards) != len(c.Boards) ||
+			got.MinHardwareRevision != c.MinHardwareRevision ||
+			got.MinSourceVersion != c.MinSourceVersion ||
+			got.MaxSourceVersion != c.MaxSourceVersion ||
+			got.PassThrough != c.PassThrough {
+			t.Fatalf("UnpackConstraints = %+v, want %+v", got, c)
+		}
+		for i := range c.Boards {
+			if !bytes.Equal(got.Boards[i], c.Boards[i]) {
+				t.Errorf("board %d = %s, want %s", i, got.Boards[i], c.Boards[i])
+			}
+		}
+	}
+}
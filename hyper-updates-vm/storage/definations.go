@@ -1,11 +1,31 @@
 package storage
 
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
 type ProjectData struct {
-	Key                string `json:"key"`
-	ProjectName        []byte `json:"name"`
-	ProjectDescription []byte `json:"description"`
-	ProjectOwner       []byte `json:"owner"`
-	Logo               []byte `json:"url"`
+	Key                string        `json:"key"`
+	ProjectName        []byte        `json:"name"`
+	ProjectDescription []byte        `json:"description"`
+	ProjectOwner       codec.Address `json:"owner"`
+	Logo               []byte        `json:"url"`
+}
+
+// ProjectRevision is one entry of the metadata history of a project.
+type ProjectRevision struct {
+	TxID        ids.ID `json:"tx_id"`     // transaction that created or edited the project
+	Timestamp   int64  `json:"timestamp"` // block timestamp
+	Description []byte `json:"description"`
+	Logo        []byte `json:"url"`
+}
+
+// ProjectUpdate is one entry of the update index of a project.
+type ProjectUpdate struct {
+	ID        ids.ID `json:"id"`        // transaction that created the update
+	Timestamp int64  `json:"timestamp"` // block timestamp
 }
 
 type UpdateData struct {
@@ -14,6 +34,100 @@ type UpdateData struct {
 	UpdateExecutableHash []byte `json:"executable_hash"`
 	UpdateIPFSUrl        []byte `json:"executable_ipfs_url"`
 	ForDeviceName        []byte `json:"for_device_name"`
-	UpdateVersion        uint8  `json:"version"`
-	SuccessCount         uint8  `json:"success_count"`
+	UpdateVersion        SemVer `json:"version"`
+	Channel              uint8  `json:"channel"`
+	SuccessCount         uint64 `json:"success_count"`
+	FailureCount         uint64 `json:"failure_count"`
+	RolloutPercentage    uint8  `json:"rollout_percentage"`
+	Signature            []byte `json:"signature"` // vendor signature, see [UpdateSigningMessage]
+	UpdatePatch
+	UpdateWindow
+
+	Revoked      bool   `json:"revoked"`
+	RevokeReason uint8  `json:"revoke_reason"`
+	RevokeNote   []byte `json:"revoke_note"`
+	RevokedAt    int64  `json:"revoked_at"`
+}
+
+// UpdatePatch describes a binary patch that rebuilds the image of an update
+// from the image of [BaseUpdate], an earlier update of the same device. It is
+// empty for updates only shipped as full images.
+type UpdatePatch struct {
+	BaseUpdate   ids.ID `json:"base_update"`
+	PatchHash    []byte `json:"patch_hash"` // encoded [Digest] of the patch
+	PatchIPFSUrl []byte `json:"patch_ipfs_url"`
+}
+
+// UpdateApprovals tracks the sign-offs of an update created while its project
+// required approvals. The update is a draft until [Threshold] approvers other
+// than [Author] have approved it.
+type UpdateApprovals struct {
+	Threshold  uint8         `json:"threshold"` // project threshold when the update was created
+	Author     codec.Address `json:"author"`
+	Approvals  []Approval    `json:"approvals"`
+	ReleasedAt int64         `json:"released_at"` // 0 while the update is a draft
+}
+
+type Approval struct {
+	Approver  codec.Address `json:"approver"`
+	Timestamp int64         `json:"timestamp"` // block timestamp
+}
+
+func (a UpdateApprovals) Draft() bool {
+	return a.ReleasedAt == 0
+}
+
+func (a UpdateApprovals) Approved(approver codec.Address) bool {
+	for _, approval := range a.Approvals {
+		if approval.Approver == approver {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateLicense restricts an update to devices whose address holds at least
+// [MinBalance] of the license asset of its project.
+type UpdateLicense struct {
+	Asset      ids.ID `json:"asset"`
+	MinBalance uint64 `json:"min_balance"`
+}
+
+func (l UpdateLicense) Empty() bool {
+	return l.Asset == ids.Empty
+}
+
+// UpdateOrigin is where an update replicated from another hyper-updates chain
+// was first published.
+type UpdateOrigin struct {
+	SourceChainID ids.ID `json:"source_chain_id"`
+	SourceTxID    ids.ID `json:"source_tx_id"`      // transaction that created the update
+	SourceProject ids.ID `json:"source_project_id"` // vendor signatures are made over it
+}
+
+func (o UpdateOrigin) Empty() bool {
+	return o.SourceChainID == ids.Empty
+}
+
+// ImportSource is a project on another hyper-updates chain whose updates a
+// project accepts, see [UpdateOrigin].
+type ImportSource struct {
+	SourceChainID ids.ID `json:"source_chain_id"`
+	SourceProject ids.ID `json:"source_project_id"`
+}
+
+type Maintainer struct {
+	Address codec.Address `json:"address"`
+	Roles   uint8         `json:"roles"`
+}
+
+type DeviceData struct {
+	ID           ids.ID            `json:"id"`
+	Project      ids.ID            `json:"project_id"`
+	PublicKey    ed25519.PublicKey `json:"public_key"`
+	Status       uint8             `json:"status"`
+	Model        []byte            `json:"model"`
+	Version      SemVer            `json:"version"`
+	RegisteredAt int64             `json:"registered_at"`
+	UpdatedAt    int64             `json:"updated_at"`
 }
@@ -0,0 +1,197 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"strings"
+
+	"lukechampine.com/blake3"
+)
+
+// DigestAlgorithm identifies the hash function of a [Digest]. The values are
+// the multihash codes of the functions.
+type DigestAlgorithm uint8
+
+const (
+	DigestSHA256 DigestAlgorithm = 0x12
+	DigestSHA512 DigestAlgorithm = 0x13
+	DigestBLAKE3 DigestAlgorithm = 0x1e
+
+	// DigestMD5 is only reported for records written before digests were
+	// tagged, those stored the hex encoded MD5 of the executable. It is never
+	// encoded.
+	DigestMD5 DigestAlgorithm = 0xd5
+
+	DefaultDigestAlgorithm = DigestSHA256
+
+	md5Size = 16
+
+	// MaxDigestLen is the size of the largest encoded digest (SHA-512)
+	MaxDigestLen = 2 + sha512.Size
+)
+
+var digestNames = map[DigestAlgorithm]string{
+	DigestSHA256: "sha256",
+	DigestSHA512: "sha512",
+	DigestBLAKE3: "blake3",
+	DigestMD5:    "md5",
+}
+
+func (a DigestAlgorithm) String() string {
+	if name, ok := digestNames[a]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Size returns the length of a sum produced by the algorithm, or 0 if the
+// algorithm is unknown.
+func (a DigestAlgorithm) Size() int {
+	switch a {
+	case DigestSHA256:
+		return sha256.Size
+	case DigestSHA512:
+		return sha512.Size
+	case DigestBLAKE3:
+		return 32
+	case DigestMD5:
+		return md5Size
+	default:
+		return 0
+	}
+}
+
+// Weak reports whether the algorithm should no longer be trusted to identify
+// an executable.
+func (a DigestAlgorithm) Weak() bool {
+	return a == DigestMD5
+}
+
+func (a DigestAlgorithm) newHash() (hash.Hash, error) {
+	switch a {
+	case DigestSHA256:
+		return sha256.New(), nil
+	case DigestSHA512:
+		return sha512.New(), nil
+	case DigestBLAKE3:
+		return blake3.New(32, nil), nil
+	default:
+		return nil, ErrUnknownDigestAlgorithm
+	}
+}
+
+// ParseDigestAlgorithm returns the algorithm with the given name. MD5 is not
+// accepted, it can't be used for new digests.
+func ParseDigestAlgorithm(name string) (DigestAlgorithm, error) {
+	a, err := lookupDigestAlgorithm(name)
+	if err != nil || a.Weak() {
+		return 0, ErrUnknownDigestAlgorithm
+	}
+	return a, nil
+}
+
+func lookupDigestAlgorithm(name string) (DigestAlgorithm, error) {
+	for a, n := range digestNames {
+		if strings.EqualFold(name, n) {
+			return a, nil
+		}
+	}
+	return 0, ErrUnknownDigestAlgorithm
+}
+
+// Digest is the hash of an executable along with the algorithm that
+// produced it.
+//
+// Digests are stored as code|length|sum, like a multihash with single byte
+// code and length.
+type Digest struct {
+	Algorithm DigestAlgorithm
+	Sum       []byte
+}
+
+// ComputeDigest hashes everything read from r with the given algorithm.
+func ComputeDigest(a DigestAlgorithm, r io.Reader) (Digest, error) {
+	h, err := a.newHash()
+	if err != nil {
+		return Digest{}, err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return Digest{}, err
+	}
+	return Digest{Algorithm: a, Sum: h.Sum(nil)}, nil
+}
+
+// Bytes returns the encoded digest. Digests of weak algorithms can't be
+// encoded, they are only read from legacy records.
+func (d Digest) Bytes() []byte {
+	b := make([]byte, 2+len(d.Sum))
+	b[0] = byte(d.Algorithm)
+	b[1] = byte(len(d.Sum))
+	copy(b[2:], d.Sum)
+	return b
+}
+
+// String returns the digest as "<algorithm>:<hex sum>".
+func (d Digest) String() string {
+	return d.Algorithm.String() + ":" + hex.EncodeToString(d.Sum)
+}
+
+func (d Digest) Weak() bool {
+	return d.Algorithm.Weak()
+}
+
+func (d Digest) Equal(o Digest) bool {
+	return d.Algorithm == o.Algorithm && bytes.Equal(d.Sum, o.Sum)
+}
+
+// ParseDigest decodes a stored digest. Values holding the hex encoded MD5 of
+// the executable, as written before digests were tagged, are returned with
+// [DigestMD5].
+func ParseDigest(b []byte) (Digest, error) {
+	if len(b) == hex.EncodedLen(md5Size) {
+		sum, err := hex.DecodeString(string(b))
+		if err != nil {
+			return Digest{}, ErrInvalidDigest
+		}
+		return Digest{Algorithm: DigestMD5, Sum: sum}, nil
+	}
+	if len(b) < 2 {
+		return Digest{}, ErrInvalidDigest
+	}
+	a := DigestAlgorithm(b[0])
+	if a.Weak() || a.Size() == 0 {
+		return Digest{}, ErrUnknownDigestAlgorithm
+	}
+	if int(b[1]) != a.Size() || len(b) != 2+a.Size() {
+		return Digest{}, ErrInvalidDigest
+	}
+	return Digest{Algorithm: a, Sum: bytes.Clone(b[2:])}, nil
+}
+
+// ParseDigestString parses the output of [Digest.String]. An untagged hex
+// string is read as a legacy MD5 digest, callers must check [Digest.Weak]
+// before trusting the result.
+func ParseDigestString(s string) (Digest, error) {
+	name, sumHex, tagged := strings.Cut(s, ":")
+	a := DigestMD5
+	if tagged {
+		var err error
+		if a, err = lookupDigestAlgorithm(name); err != nil {
+			return Digest{}, err
+		}
+	} else {
+		sumHex = name
+	}
+	sum, err := hex.DecodeString(sumHex)
+	if err != nil || len(sum) != a.Size() {
+		return Digest{}, ErrInvalidDigest
+	}
+	return Digest{Algorithm: a, Sum: sum}, nil
+}
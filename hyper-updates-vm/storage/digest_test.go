@@ -0,0 +1,199 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const (
+	abcSHA256 = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	abcSHA512 = "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"
+	abcBLAKE3 = "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85"
+	abcMD5    = "900150983cd24fb0d6963f7d28e17f72"
+
+	sha256Size = 32 // length byte of an encoded SHA-256 digest
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestComputeDigest(t *testing.T) {
+	tests := []struct {
+		a   DigestAlgorithm
+		sum string
+		err error
+	}{
+		{a: DigestSHA256, sum: abcSHA256},
+		{a: DigestSHA512, sum: abcSHA512},
+		{a: DigestBLAKE3, sum: abcBLAKE3},
+		{a: DigestMD5, err: ErrUnknownDigestAlgorithm},
+		{a: 0x00, err: ErrUnknownDigestAlgorithm},
+	}
+	for _, tt := range tests {
+		d, err := ComputeDigest(tt.a, strings.NewReader("abc"))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ComputeDigest(%s) error = %v, want %v", tt.a, err, tt.err)
+			continue
+		}
+		if tt.err != nil {
+			continue
+		}
+		if got := hex.EncodeToString(d.Sum); got != tt.sum {
+			t.Errorf("ComputeDigest(%s) = %s, want %s", tt.a, got, tt.sum)
+		}
+	}
+}
+
+func TestParseDigest(t *testing.T) {
+	sha256Sum := mustHex(abcSHA256)
+	tests := []struct {
+		name string
+		b    []byte
+		want Digest
+		err  error
+	}{
+		{
+			name: "sha256",
+			b:    Digest{Algorithm: DigestSHA256, Sum: sha256Sum}.Bytes(),
+			want: Digest{Algorithm: DigestSHA256, Sum: sha256Sum},
+		},
+		{
+			name: "sha512",
+			b:    Digest{Algorithm: DigestSHA512, Sum: mustHex(abcSHA512)}.Bytes(),
+			want: Digest{Algorithm: DigestSHA512, Sum: mustHex(abcSHA512)},
+		},
+		{
+			name: "blake3",
+			b:    Digest{Algorithm: DigestBLAKE3, Sum: mustHex(abcBLAKE3)}.Bytes(),
+			want: Digest{Algorithm: DigestBLAKE3, Sum: mustHex(abcBLAKE3)},
+		},
+		{
+			name: "legacy md5 hex",
+			b:    []byte(abcMD5),
+			want: Digest{Algorithm: DigestMD5, Sum: mustHex(abcMD5)},
+		},
+		{name: "legacy md5 not hex", b: []byte(strings.Repeat("z", 32)), err: ErrInvalidDigest},
+		{name: "empty", b: nil, err: ErrInvalidDigest},
+		{name: "tag only", b: []byte{byte(DigestSHA256)}, err: ErrInvalidDigest},
+		{name: "short sum", b: append([]byte{byte(DigestSHA256), sha256Size}, sha256Sum[:31]...), err: ErrInvalidDigest},
+		{name: "long sum", b: append([]byte{byte(DigestSHA256), sha256Size}, append(sha256Sum, 0)...), err: ErrInvalidDigest},
+		{name: "wrong length byte", b: append([]byte{byte(DigestSHA256), 31}, sha256Sum...), err: ErrInvalidDigest},
+		{name: "sha512 length for sha256", b: append([]byte{byte(DigestSHA512), sha256Size}, sha256Sum...), err: ErrInvalidDigest},
+		{name: "unknown tag", b: append([]byte{0x11, sha256Size}, sha256Sum...), err: ErrUnknownDigestAlgorithm},
+		{name: "tagged md5", b: append([]byte{byte(DigestMD5), 16}, mustHex(abcMD5)...), err: ErrUnknownDigestAlgorithm},
+	}
+	for _, tt := range tests {
+		got, err := ParseDigest(tt.b)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: ParseDigest error = %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if tt.err == nil && !got.Equal(tt.want) {
+			t.Errorf("%s: ParseDigest = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseDigestString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want Digest
+		err  error
+	}{
+		{s: "sha256:" + abcSHA256, want: Digest{Algorithm: DigestSHA256, Sum: mustHex(abcSHA256)}},
+		{s: "SHA256:" + abcSHA256, want: Digest{Algorithm: DigestSHA256, Sum: mustHex(abcSHA256)}},
+		{s: "sha512:" + abcSHA512, want: Digest{Algorithm: DigestSHA512, Sum: mustHex(abcSHA512)}},
+		{s: "blake3:" + abcBLAKE3, want: Digest{Algorithm: DigestBLAKE3, Sum: mustHex(abcBLAKE3)}},
+		{s: abcMD5, want: Digest{Algorithm: DigestMD5, Sum: mustHex(abcMD5)}},
+		{s: "md5:" + abcMD5, want: Digest{Algorithm: DigestMD5, Sum: mustHex(abcMD5)}},
+
+		{s: "", err: ErrInvalidDigest},
+		{s: abcSHA256, err: ErrInvalidDigest},
+		{s: "sha256:" + abcSHA256[:62], err: ErrInvalidDigest},
+		{s: "sha256:" + abcSHA256 + "00", err: ErrInvalidDigest},
+		{s: "sha256:" + abcSHA512, err: ErrInvalidDigest},
+		{s: "sha256:" + strings.Repeat("z", 64), err: ErrInvalidDigest},
+		{s: "sha256:", err: ErrInvalidDigest},
+		{s: "crc32:" + abcSHA256, err: ErrUnknownDigestAlgorithm},
+		{s: ":" + abcSHA256, err: ErrUnknownDigestAlgorithm},
+	}
+	for _, tt := range tests {
+		got, err := ParseDigestString(tt.s)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseDigestString(%q) error = %v, want %v", tt.s, err, tt.err)
+			continue
+		}
+		if tt.err != nil {
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseDigestString(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+		if got.Algorithm != DigestMD5 && got.String() != strings.ToLower(tt.s) {
+			t.Errorf("ParseDigestString(%q).String() = %s", tt.s, got)
+		}
+	}
+}
+
+func TestParseDigestAlgorithm(t *testing.T) {
+	for _, name := range []string{"sha256", "SHA512", "blake3"} {
+		if _, err := ParseDigestAlgorithm(name); err != nil {
+			t.Errorf("ParseDigestAlgorithm(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"md5", "crc32", ""} {
+		if _, err := ParseDigestAlgorithm(name); !errors.Is(err, ErrUnknownDigestAlgorithm) {
+			t.Errorf("ParseDigestAlgorithm(%q) error = %v, want %v", name, err, ErrUnknownDigestAlgorithm)
+		}
+	}
+}
+
+func TestDigestEqual(t *testing.T) {
+	sum := mustHex(abcSHA256)
+	d := Digest{Algorithm: DigestSHA256, Sum: sum}
+	tests := []struct {
+		name string
+		o    Digest
+		want bool
+	}{
+		{"same", Digest{Algorithm: DigestSHA256, Sum: bytes.Clone(sum)}, true},
+		{"other algorithm", Digest{Algorithm: DigestBLAKE3, Sum: sum}, false},
+		{"other sum", Digest{Algorithm: DigestSHA256, Sum: mustHex(abcBLAKE3)}, false},
+		{"truncated sum", Digest{Algorithm: DigestSHA256, Sum: sum[:16]}, false},
+		{"empty", Digest{}, false},
+	}
+	for _, tt := range tests {
+		if got := d.Equal(tt.o); got != tt.want {
+			t.Errorf("%s: Equal = %t, want %t", tt.name, got, tt.want)
+		}
+		if got := tt.o.Equal(d); got != tt.want {
+			t.Errorf("%s: Equal is not symmetric", tt.name)
+		}
+	}
+}
+
+func TestDigestWeak(t *testing.T) {
+	for _, a := range []DigestAlgorithm{DigestSHA256, DigestSHA512, DigestBLAKE3} {
+		if (Digest{Algorithm: a}).Weak() {
+			t.Errorf("%s reported weak", a)
+		}
+	}
+	legacy, err := ParseDigest([]byte(abcMD5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !legacy.Weak() {
+		t.Error("legacy md5 digest not reported weak")
+	}
+}
@@ -5,4 +5,30 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance           = errors.New("invalid balance")
+	ErrTooManyMaintainers       = errors.New("too many maintainers")
+	ErrInvalidSemVer            = errors.New("invalid semantic version")
+	ErrRevocationNoteTooLong    = errors.New("revocation note too long")
+	ErrDeviceModelTooLong       = errors.New("device model too long")
+	ErrUnknownRecordVersion     = errors.New("unknown record version")
+	ErrRolloutPercentageInvalid = errors.New("rollout percentage invalid")
+	ErrUnknownDigestAlgorithm   = errors.New("unknown digest algorithm")
+	ErrInvalidDigest            = errors.New("invalid digest")
+	ErrTooManySigningKeys       = errors.New("too many signing keys")
+	ErrUnknownArtifactType      = errors.New("unknown artifact type")
+	ErrTooManyArtifacts         = errors.New("too many artifacts")
+	ErrInvalidManifest          = errors.New("invalid manifest")
+	ErrTooManyBoards            = errors.New("too many boards")
+	ErrInvalidConstraints       = errors.New("invalid update constraints")
+	ErrBoardNotAllowed          = errors.New("device model is not supported by the update")
+	ErrHardwareRevisionTooLow   = errors.New("hardware revision is below the update minimum")
+	ErrSourceVersionTooLow      = errors.New("device version is below the update minimum")
+	ErrSourceVersionTooHigh     = errors.New("device version is above the update maximum")
+	ErrInvalidCursor            = errors.New("invalid cursor")
+	ErrInvalidUpdateWindow      = errors.New("invalid update window")
+	ErrUpdateNotYetValid        = errors.New("update is not available yet")
+	ErrUpdateExpired            = errors.New("update has expired")
+	ErrApprovalThresholdInvalid = errors.New("approval threshold invalid")
+	ErrTooManyImportSources     = errors.New("too many import sources")
+)
@@ -0,0 +1,193 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// ArtifactType identifies what an [Artifact] is written to. The values follow
+// the order artifacts are installed in: the partition table first since it
+// places everything else, the app last since flashing it reboots the device.
+type ArtifactType uint8
+
+const (
+	ArtifactPartitionTable ArtifactType = iota + 1
+	ArtifactBootloader
+	ArtifactFilesystem // SPIFFS or LittleFS image
+	ArtifactApp
+
+	MaxArtifactType = ArtifactApp
+
+	// MaxManifestArtifacts bounds a manifest so it always fits in
+	// [ManifestChunks].
+	MaxManifestArtifacts = 8
+	MaxPartitionLabelLen = 16 // as in ESP-IDF partition tables
+
+	maxArtifactLen = consts.Uint8Len +
+		consts.IntLen + MaxPartitionLabelLen +
+		consts.IntLen + // offset
+		consts.Uint64Len +
+		consts.IntLen + MaxDigestLen +
+		consts.IntLen + UpdateExecutableIPFSUrlChunks
+)
+
+var artifactTypeNames = map[ArtifactType]string{
+	ArtifactPartitionTable: "partition-table",
+	ArtifactBootloader:     "bootloader",
+	ArtifactFilesystem:     "filesystem",
+	ArtifactApp:            "app",
+}
+
+func (t ArtifactType) String() string {
+	if name, ok := artifactTypeNames[t]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func ParseArtifactType(name string) (ArtifactType, error) {
+	for t, n := range artifactTypeNames {
+		if strings.EqualFold(name, n) {
+			return t, nil
+		}
+	}
+	return 0, ErrUnknownArtifactType
+}
+
+// Artifact is one image of a multi-artifact release.
+type Artifact struct {
+	Type ArtifactType `json:"type"`
+
+	// Partition is the label of the partition the artifact is written to and
+	// Offset its flash address. Both may be left empty when the device picks
+	// the target, like the next OTA slot for an app.
+	Partition []byte `json:"partition"`
+	Offset    uint32 `json:"offset"`
+
+	Size    uint64 `json:"size"`
+	Digest  []byte `json:"digest"` // encoded [Digest]
+	IPFSUrl []byte `json:"ipfs_url"`
+}
+
+// Manifest lists the artifacts of a release in install order.
+type Manifest []Artifact
+
+// Validate checks that every artifact is well formed and that the artifacts
+// are listed in install order. Only filesystems may be shipped more than once.
+func (m Manifest) Validate() error {
+	if len(m) > MaxManifestArtifacts {
+		return ErrTooManyArtifacts
+	}
+	var last ArtifactType
+	for _, a := range m {
+		if a.Type == 0 || a.Type > MaxArtifactType {
+			return ErrUnknownArtifactType
+		}
+		if a.Type < last || (a.Type == last && a.Type != ArtifactFilesystem) {
+			return ErrInvalidManifest
+		}
+		last = a.Type
+		if len(a.Partition) > MaxPartitionLabelLen || a.Size == 0 || len(a.IPFSUrl) == 0 {
+			return ErrInvalidManifest
+		}
+		if digest, err := ParseDigest(a.Digest); err != nil || digest.Weak() {
+			return ErrInvalidDigest
+		}
+	}
+	return nil
+}
+
+// App returns the app artifact of the manifest.
+func (m Manifest) App() (Artifact, bool) {
+	for _, a := range m {
+		if a.Type == ArtifactApp {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// Digest is what a vendor signs for a multi-artifact release, see
+// [UpdateSigningMessage]. Locations are left out, they are only known once the
+// artifacts are uploaded and don't change what is installed.
+func (m Manifest) Digest() Digest {
+	size := consts.IntLen
+	for _, a := range m {
+		size += artifactLen(a, false)
+	}
+	p := codec.NewWriter(size, size)
+	p.PackInt(len(m))
+	for _, a := range m {
+		packArtifact(p, a, false)
+	}
+	// Hashing from memory can't fail
+	digest, _ := ComputeDigest(DefaultDigestAlgorithm, bytes.NewReader(p.Bytes()))
+	return digest
+}
+
+// Size returns the number of bytes [PackManifest] writes for the manifest.
+func (m Manifest) Size() int {
+	size := consts.IntLen
+	for _, a := range m {
+		size += artifactLen(a, true)
+	}
+	return size
+}
+
+func PackManifest(p *codec.Packer, m Manifest) {
+	p.PackInt(len(m))
+	for _, a := range m {
+		packArtifact(p, a, true)
+	}
+}
+
+// UnpackManifest reads a manifest written by [PackManifest]. Contents are not
+// validated, see [Manifest.Validate].
+func UnpackManifest(p *codec.Packer) (Manifest, error) {
+	count := p.UnpackInt(false)
+	if count > MaxManifestArtifacts {
+		return nil, ErrTooManyArtifacts
+	}
+	if count == 0 {
+		return nil, p.Err()
+	}
+	m := make(Manifest, count)
+	for i := range m {
+		m[i].Type = ArtifactType(p.UnpackByte())
+		p.UnpackBytes(MaxPartitionLabelLen, false, &m[i].Partition)
+		m[i].Offset = uint32(p.UnpackInt(false))
+		m[i].Size = p.UnpackUint64(true)
+		p.UnpackBytes(MaxDigestLen, true, &m[i].Digest)
+		p.UnpackBytes(UpdateExecutableIPFSUrlChunks, true, &m[i].IPFSUrl)
+	}
+	return m, p.Err()
+}
+
+func packArtifact(p *codec.Packer, a Artifact, location bool) {
+	p.PackByte(byte(a.Type))
+	p.PackBytes(a.Partition)
+	p.PackInt(int(a.Offset))
+	p.PackUint64(a.Size)
+	p.PackBytes(a.Digest)
+	if location {
+		p.PackBytes(a.IPFSUrl)
+	}
+}
+
+func artifactLen(a Artifact, location bool) int {
+	size := consts.Uint8Len +
+		codec.BytesLen(a.Partition) +
+		consts.IntLen +
+		consts.Uint64Len +
+		codec.BytesLen(a.Digest)
+	if location {
+		size += codec.BytesLen(a.IPFSUrl)
+	}
+	return size
+}
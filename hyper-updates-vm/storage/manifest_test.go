@@ -0,0 +1,165 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/hypersdk/codec"
+)
+
+func testArtifact(t ArtifactType, content string) Artifact {
+	return Artifact{
+		Type:    t,
+		Size:    uint64(len(content)),
+		Digest:  mustComputeDigest(content).Bytes(),
+		IPFSUrl: []byte("https://ipfs.io/ipfs/" + content),
+	}
+}
+
+func mustComputeDigest(content string) Digest {
+	d, err := ComputeDigest(DefaultDigestAlgorithm, bytes.NewReader([]byte(content)))
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestManifestValidate(t *testing.T) {
+	app := testArtifact(ArtifactApp, "app")
+	fs := testArtifact(ArtifactFilesystem, "spiffs")
+	with := func(a Artifact, f func(*Artifact)) Artifact {
+		f(&a)
+		return a
+	}
+	tests := []struct {
+		name string
+		m    Manifest
+		err  error
+	}{
+		{name: "empty", m: nil},
+		{name: "app", m: Manifest{app}},
+		{
+			name: "every type in order",
+			m: Manifest{
+				testArtifact(ArtifactPartitionTable, "partitions"),
+				testArtifact(ArtifactBootloader, "bootloader"),
+				fs,
+				app,
+			},
+		},
+		{name: "two filesystems", m: Manifest{fs, testArtifact(ArtifactFilesystem, "littlefs"), app}},
+		{
+			name: "partition and offset",
+			m: Manifest{with(fs, func(a *Artifact) {
+				a.Partition = bytes.Repeat([]byte("p"), MaxPartitionLabelLen)
+				a.Offset = 0x290000
+			})},
+		},
+
+		{name: "out of order", m: Manifest{app, fs}, err: ErrInvalidManifest},
+		{name: "two apps", m: Manifest{app, app}, err: ErrInvalidManifest},
+		{name: "type 0", m: Manifest{with(app, func(a *Artifact) { a.Type = 0 })}, err: ErrUnknownArtifactType},
+		{name: "unknown type", m: Manifest{with(app, func(a *Artifact) { a.Type = MaxArtifactType + 1 })}, err: ErrUnknownArtifactType},
+		{name: "empty size", m: Manifest{with(app, func(a *Artifact) { a.Size = 0 })}, err: ErrInvalidManifest},
+		{name: "no url", m: Manifest{with(app, func(a *Artifact) { a.IPFSUrl = nil })}, err: ErrInvalidManifest},
+		{
+			name: "long partition label",
+			m:    Manifest{with(app, func(a *Artifact) { a.Partition = bytes.Repeat([]byte("p"), MaxPartitionLabelLen+1) })},
+			err:  ErrInvalidManifest,
+		},
+		{name: "no digest", m: Manifest{with(app, func(a *Artifact) { a.Digest = nil })}, err: ErrInvalidDigest},
+		{
+			name: "md5 digest",
+			m:    Manifest{with(app, func(a *Artifact) { a.Digest = []byte("900150983cd24fb0d6963f7d28e17f72") })},
+			err:  ErrInvalidDigest,
+		},
+		{
+			name: "too many artifacts",
+			m: func() Manifest {
+				m := make(Manifest, MaxManifestArtifacts+1)
+				for i := range m {
+					m[i] = fs
+				}
+				return m
+			}(),
+			err: ErrTooManyArtifacts,
+		},
+	}
+	for _, tt := range tests {
+		if err := tt.m.Validate(); !errors.Is(err, tt.err) {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestManifestDigest(t *testing.T) {
+	m := Manifest{testArtifact(ArtifactFilesystem, "spiffs"), testArtifact(ArtifactApp, "app")}
+	d := m.Digest()
+	if d.Algorithm != DefaultDigestAlgorithm || len(d.Sum) != DefaultDigestAlgorithm.Size() {
+		t.Fatalf("Digest() = %s", d)
+	}
+
+	// Locations don't change what is installed
+	moved := Manifest{m[0], m[1]}
+	moved[1].IPFSUrl = []byte("https://example.com/app.bin")
+	if !moved.Digest().Equal(d) {
+		t.Error("digest depends on the artifact urls")
+	}
+
+	changes := map[string]func(Manifest){
+		"type":      func(m Manifest) { m[0].Type = ArtifactBootloader },
+		"partition": func(m Manifest) { m[1].Partition = []byte("ota_1") },
+		"offset":    func(m Manifest) { m[1].Offset = 0x110000 },
+		"size":      func(m Manifest) { m[1].Size++ },
+		"digest":    func(m Manifest) { m[1].Digest = mustComputeDigest("other").Bytes() },
+		"order":     func(m Manifest) { m[0], m[1] = m[1], m[0] },
+	}
+	for name, change := range changes {
+		changed := Manifest{m[0], m[1]}
+		change(changed)
+		if changed.Digest().Equal(d) {
+			t.Errorf("digest ignores the %s", name)
+		}
+	}
+	if (Manifest{m[1]}).Digest().Equal(d) {
+		t.Error("digest ignores dropped artifacts")
+	}
+}
+
+func TestManifestEncoding(t *testing.T) {
+	m := Manifest{
+		testArtifact(ArtifactPartitionTable, "partitions"),
+		testArtifact(ArtifactFilesystem, "spiffs"),
+		testArtifact(ArtifactApp, "app"),
+	}
+	m[1].Partition = []byte("spiffs")
+	m[1].Offset = 0x290000
+
+	p := codec.NewWriter(m.Size(), m.Size())
+	PackManifest(p, m)
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Bytes()) != m.Size() {
+		t.Fatalf("packed %d bytes, Size() = %d", len(p.Bytes()), m.Size())
+	}
+	got, err := UnpackManifest(codec.NewReader(p.Bytes(), m.Size()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Digest().Equal(m.Digest()) || len(got) != len(m) {
+		t.Fatalf("UnpackManifest = %+v, want %+v", got, m)
+	}
+	for i := range m {
+		if !bytes.Equal(got[i].IPFSUrl, m[i].IPFSUrl) {
+			t.Errorf("artifact %d url = %s, want %s", i, got[i].IPFSUrl, m[i].IPFSUrl)
+		}
+	}
+	if app, ok := got.App(); !ok || app.Type != ArtifactApp {
+		t.Errorf("App() = %+v, %t", app, ok)
+	}
+}
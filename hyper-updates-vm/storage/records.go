@@ -0,0 +1,281 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+
+	tconsts "hyper-updates/consts"
+
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+// Project and update records start with a schema version byte followed by
+// length-prefixed fields. Records written before the schema version existed
+// are fixed width and zero padded, they start with the first byte of a
+// required field and are told apart by their length.
+const (
+	projectRecordV1 uint8 = 1
+	updateRecordV1  uint8 = 1
+	updateRecordV2  uint8 = 2 // adds the vendor signature
+	updateRecordV3  uint8 = 3 // adds the delta patch
+	updateRecordV4  uint8 = 4 // adds the validity window
+
+	manifestRecordV1    uint8 = 1
+	constraintsRecordV1 uint8 = 1
+	approvalsRecordV1   uint8 = 1
+
+	chunkSize = 64 // bytes, see [keys.NumChunks]
+
+	maxProjectLen = consts.Uint8Len +
+		consts.IntLen + MaxProjectNameLen +
+		consts.IntLen + MaxProjectDescriptionLen +
+		codec.AddressLen +
+		consts.IntLen + MaxProjectLogoLen
+	maxUpdateLen = consts.Uint8Len +
+		consts.IntLen + ProjectTxIDChunks +
+		consts.IntLen + UpdateExecutableHashChunks +
+		consts.IntLen + UpdateExecutableIPFSUrlChunks +
+		consts.IntLen + ForDeviceNameChunks +
+		consts.IntLen + MaxSemVerLen +
+		consts.Uint8Len +
+		consts.IntLen + ed25519.SignatureLen +
+		consts.IDLen +
+		consts.IntLen + MaxDigestLen +
+		consts.IntLen + UpdateExecutableIPFSUrlChunks +
+		2*consts.Int64Len
+	maxManifestLen = consts.Uint8Len +
+		consts.IntLen + MaxManifestArtifacts*maxArtifactLen
+	maxConstraintsRecordLen = consts.Uint8Len + maxConstraintsLen
+	maxApprovalsLen         = consts.Uint8Len +
+		consts.Uint8Len +
+		codec.AddressLen +
+		consts.IntLen + MaxApprovalThreshold*(codec.AddressLen+consts.Int64Len) +
+		consts.Int64Len
+
+	// ProjectChunks and UpdateChunks are the most a record can take up.
+	// Records stored before they were used in [ProjectKey] and [UpdateKey]
+	// are found under [LegacyProjectKey] and [LegacyUpdateKey].
+	ProjectChunks uint16 = uint16(maxProjectLen/chunkSize + 1)
+	UpdateChunks  uint16 = uint16(maxUpdateLen/chunkSize + 1)
+
+	ManifestChunks    uint16 = uint16(maxManifestLen/chunkSize + 1)
+	ConstraintsChunks uint16 = uint16(maxConstraintsRecordLen/chunkSize + 1)
+	ApprovalsChunks   uint16 = uint16(maxApprovalsLen/chunkSize + 1)
+
+	// Size of a legacy project record: name (32), description (100),
+	// bech32 owner (500) and logo (100)
+	legacyProjectLen = int(ProjectNameChunks +
+		ProjectDescriptionChunks +
+		ProjectOwnerChunks +
+		ProjectLogoChunks) // 732
+
+	// Size of a legacy update record: project, hash, url and device (100
+	// each), then the version and the success count in a byte each
+	legacyUpdateLen = ProjectTxIDChunks +
+		UpdateExecutableHashChunks +
+		UpdateExecutableIPFSUrlChunks +
+		ForDeviceNameChunks +
+		UpdateVersionUnitsChunks +
+		SuccessCountUnitsChunks // 402
+
+	// Offset of the version byte in legacy update records
+	legacyUpdateVersionOffset = ProjectTxIDChunks +
+		UpdateExecutableHashChunks +
+		UpdateExecutableIPFSUrlChunks +
+		ForDeviceNameChunks
+)
+
+func encodeProject(data ProjectData) ([]byte, error) {
+	p := codec.NewWriter(maxProjectLen, maxProjectLen)
+	p.PackByte(projectRecordV1)
+	p.PackBytes(data.ProjectName)
+	p.PackBytes(data.ProjectDescription)
+	p.PackAddress(data.ProjectOwner)
+	p.PackBytes(data.Logo)
+	return p.Bytes(), p.Err()
+}
+
+func decodeProject(v []byte) (ProjectData, error) {
+	if len(v) == legacyProjectLen && v[0] != projectRecordV1 {
+		return decodeLegacyProject(v)
+	}
+	var data ProjectData
+	p := codec.NewReader(v, maxProjectLen)
+	if p.UnpackByte() != projectRecordV1 {
+		return ProjectData{}, ErrUnknownRecordVersion
+	}
+	p.UnpackBytes(MaxProjectNameLen, true, &data.ProjectName)
+	p.UnpackBytes(MaxProjectDescriptionLen, false, &data.ProjectDescription)
+	p.UnpackAddress(&data.ProjectOwner)
+	p.UnpackBytes(MaxProjectLogoLen, false, &data.Logo)
+	return data, p.Err()
+}
+
+// decodeLegacyProject reads a fixed width project record, which held the
+// owner as a zero padded bech32 string.
+func decodeLegacyProject(v []byte) (ProjectData, error) {
+	owner, err := codec.ParseAddressBech32(
+		tconsts.HRP,
+		string(trimPadding(v[ProjectNameChunks+ProjectDescriptionChunks:ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks])),
+	)
+	if err != nil {
+		return ProjectData{}, err
+	}
+	return ProjectData{
+		ProjectName:        trimPadding(v[:ProjectNameChunks]),
+		ProjectDescription: trimPadding(v[ProjectNameChunks : ProjectNameChunks+ProjectDescriptionChunks]),
+		ProjectOwner:       owner,
+		Logo:               trimPadding(v[ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks:]),
+	}, nil
+}
+
+func encodeUpdate(data UpdateData) ([]byte, error) {
+	version := make([]byte, semVerLen(data.UpdateVersion))
+	encodeSemVer(version, data.UpdateVersion)
+
+	p := codec.NewWriter(maxUpdateLen, maxUpdateLen)
+	p.PackByte(updateRecordV4)
+	p.PackBytes(data.ProjectTxID)
+	p.PackBytes(data.UpdateExecutableHash)
+	p.PackBytes(data.UpdateIPFSUrl)
+	p.PackBytes(data.ForDeviceName)
+	p.PackBytes(version)
+	p.PackByte(data.Channel)
+	p.PackBytes(data.Signature)
+	p.PackID(data.BaseUpdate)
+	p.PackBytes(data.PatchHash)
+	p.PackBytes(data.PatchIPFSUrl)
+	p.PackInt64(data.NotBefore)
+	p.PackInt64(data.ExpiresAt)
+	return p.Bytes(), p.Err()
+}
+
+// decodeUpdate reads the fields stored in the update record. Install results
+// are only kept in legacy records, the success count of those is returned in
+// [UpdateData.SuccessCount].
+func decodeUpdate(v []byte) (UpdateData, error) {
+	if len(v) == legacyUpdateLen && (v[0] < updateRecordV1 || v[0] > updateRecordV4) {
+		return decodeLegacyUpdate(v)
+	}
+	var (
+		data    UpdateData
+		version []byte
+	)
+	p := codec.NewReader(v, maxUpdateLen)
+	schema := p.UnpackByte()
+	if schema < updateRecordV1 || schema > updateRecordV4 {
+		return UpdateData{}, ErrUnknownRecordVersion
+	}
+	p.UnpackBytes(ProjectTxIDChunks, true, &data.ProjectTxID)
+	p.UnpackBytes(UpdateExecutableHashChunks, true, &data.UpdateExecutableHash)
+	p.UnpackBytes(UpdateExecutableIPFSUrlChunks, true, &data.UpdateIPFSUrl)
+	p.UnpackBytes(ForDeviceNameChunks, true, &data.ForDeviceName)
+	p.UnpackBytes(MaxSemVerLen, true, &version)
+	data.Channel = p.UnpackByte()
+	if schema >= updateRecordV2 {
+		p.UnpackBytes(ed25519.SignatureLen, false, &data.Signature)
+	}
+	if schema >= updateRecordV3 {
+		p.UnpackID(false, &data.BaseUpdate)
+		p.UnpackBytes(MaxDigestLen, false, &data.PatchHash)
+		p.UnpackBytes(UpdateExecutableIPFSUrlChunks, false, &data.PatchIPFSUrl)
+	}
+	if schema >= updateRecordV4 {
+		data.NotBefore = p.UnpackInt64(false)
+		data.ExpiresAt = p.UnpackInt64(false)
+	}
+	if err := p.Err(); err != nil {
+		return UpdateData{}, err
+	}
+	var err error
+	data.UpdateVersion, err = decodeSemVer(version)
+	return data, err
+}
+
+// decodeLegacyUpdate reads a fixed width update record, which was always
+// released on the stable channel.
+func decodeLegacyUpdate(v []byte) (UpdateData, error) {
+	return UpdateData{
+		ProjectTxID:          trimPadding(v[:ProjectTxIDChunks]),
+		UpdateExecutableHash: trimPadding(v[ProjectTxIDChunks : ProjectTxIDChunks+UpdateExecutableHashChunks]),
+		UpdateIPFSUrl:        trimPadding(v[ProjectTxIDChunks+UpdateExecutableHashChunks : ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks]),
+		ForDeviceName:        trimPadding(v[ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks : legacyUpdateVersionOffset]),
+		UpdateVersion:        LegacyVersion(v[legacyUpdateVersionOffset]),
+		SuccessCount:         uint64(v[legacyUpdateVersionOffset+UpdateVersionUnitsChunks]),
+	}, nil
+}
+
+func encodeManifest(m Manifest) ([]byte, error) {
+	p := codec.NewWriter(maxManifestLen, maxManifestLen)
+	p.PackByte(manifestRecordV1)
+	PackManifest(p, m)
+	return p.Bytes(), p.Err()
+}
+
+func decodeManifest(v []byte) (Manifest, error) {
+	p := codec.NewReader(v, maxManifestLen)
+	if p.UnpackByte() != manifestRecordV1 {
+		return nil, ErrUnknownRecordVersion
+	}
+	return UnpackManifest(p)
+}
+
+func encodeConstraints(c UpdateConstraints) ([]byte, error) {
+	p := codec.NewWriter(maxConstraintsRecordLen, maxConstraintsRecordLen)
+	p.PackByte(constraintsRecordV1)
+	PackConstraints(p, c)
+	return p.Bytes(), p.Err()
+}
+
+func decodeConstraints(v []byte) (UpdateConstraints, error) {
+	p := codec.NewReader(v, maxConstraintsRecordLen)
+	if p.UnpackByte() != constraintsRecordV1 {
+		return UpdateConstraints{}, ErrUnknownRecordVersion
+	}
+	return UnpackConstraints(p)
+}
+
+func encodeApprovals(a UpdateApprovals) ([]byte, error) {
+	p := codec.NewWriter(maxApprovalsLen, maxApprovalsLen)
+	p.PackByte(approvalsRecordV1)
+	p.PackByte(a.Threshold)
+	p.PackAddress(a.Author)
+	p.PackInt(len(a.Approvals))
+	for _, approval := range a.Approvals {
+		p.PackAddress(approval.Approver)
+		p.PackInt64(approval.Timestamp)
+	}
+	p.PackInt64(a.ReleasedAt)
+	return p.Bytes(), p.Err()
+}
+
+func decodeApprovals(v []byte) (UpdateApprovals, error) {
+	var a UpdateApprovals
+	p := codec.NewReader(v, maxApprovalsLen)
+	if p.UnpackByte() != approvalsRecordV1 {
+		return UpdateApprovals{}, ErrUnknownRecordVersion
+	}
+	a.Threshold = p.UnpackByte()
+	p.UnpackAddress(&a.Author)
+	count := p.UnpackInt(false)
+	if count > MaxApprovalThreshold {
+		return UpdateApprovals{}, ErrApprovalThresholdInvalid
+	}
+	if count > 0 {
+		a.Approvals = make([]Approval, count)
+	}
+	for i := range a.Approvals {
+		p.UnpackAddress(&a.Approvals[i].Approver)
+		a.Approvals[i].Timestamp = p.UnpackInt64(false)
+	}
+	a.ReleasedAt = p.UnpackInt64(false)
+	return a, p.Err()
+}
+
+func trimPadding(b []byte) []byte {
+	return bytes.TrimRight(b, "\x00")
+}
@@ -0,0 +1,434 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	tconsts "hyper-updates/consts"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+var testOwner = codec.CreateAddress(0, ids.GenerateTestID())
+
+// legacyProjectRecord lays out a project the way SetProject did before
+// records were versioned: name, description, bech32 owner and logo copied
+// into 32+100+500+100 zero padded bytes.
+func legacyProjectRecord(name, description, owner, logo string) []byte {
+	v := make([]byte, 32+100+500+100)
+	copy(v[:32], name)
+	copy(v[32:132], description)
+	copy(v[132:632], owner)
+	copy(v[632:], logo)
+	return v
+}
+
+func TestDecodeLegacyProject(t *testing.T) {
+	owner := codec.MustAddressBech32(tconsts.HRP, testOwner)
+	v := legacyProjectRecord("thermostat", "firmware for the v2 board", owner, "https://example.com/logo.png")
+	if len(v) != 732 {
+		t.Fatalf("legacy record is %d bytes, want 732", len(v))
+	}
+
+	data, err := decodeProject(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.ProjectOwner != testOwner {
+		t.Fatalf("owner = %x, want %x", data.ProjectOwner, testOwner)
+	}
+	if !bytes.Equal(data.ProjectName, []byte("thermostat")) {
+		t.Fatalf("name = %q", data.ProjectName)
+	}
+	if !bytes.Equal(data.ProjectDescription, []byte("firmware for the v2 board")) {
+		t.Fatalf("description = %q", data.ProjectDescription)
+	}
+	if !bytes.Equal(data.Logo, []byte("https://example.com/logo.png")) {
+		t.Fatalf("logo = %q", data.Logo)
+	}
+}
+
+func TestDecodeLegacyProjectInvalidOwner(t *testing.T) {
+	v := legacyProjectRecord("thermostat", "firmware", "not an address", "")
+	if _, err := decodeProject(v); err == nil {
+		t.Fatal("decoded a legacy record without a bech32 owner")
+	}
+}
+
+// legacyUpdateRecord lays out an update the way SetUpdate did before records
+// were versioned: project, hash, url and device in 100 byte zero padded
+// fields, followed by the version and the success count.
+func legacyUpdateRecord(project, hash, url, device string, version, successCount byte) []byte {
+	v := make([]byte, 100+100+100+100+1+1)
+	copy(v[:100], project)
+	copy(v[100:200], hash)
+	copy(v[200:300], url)
+	copy(v[300:400], device)
+	v[400] = version
+	v[401] = successCount
+	return v
+}
+
+// versionedUpdateRecord lays out an update the way [encodeUpdate] did for
+// [schema].
+func versionedUpdateRecord(t *testing.T, schema uint8, data UpdateData) []byte {
+	version := make([]byte, semVerLen(data.UpdateVersion))
+	encodeSemVer(version, data.UpdateVersion)
+
+	p := codec.NewWriter(maxUpdateLen, maxUpdateLen)
+	p.PackByte(schema)
+	p.PackBytes(data.ProjectTxID)
+	p.PackBytes(data.UpdateExecutableHash)
+	p.PackBytes(data.UpdateIPFSUrl)
+	p.PackBytes(data.ForDeviceName)
+	p.PackBytes(version)
+	p.PackByte(data.Channel)
+	if schema >= updateRecordV2 {
+		p.PackBytes(data.Signature)
+	}
+	if schema >= updateRecordV3 {
+		p.PackID(data.BaseUpdate)
+		p.PackBytes(data.PatchHash)
+		p.PackBytes(data.PatchIPFSUrl)
+	}
+	if schema >= updateRecordV4 {
+		p.PackInt64(data.NotBefore)
+		p.PackInt64(data.ExpiresAt)
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return p.Bytes()
+}
+
+func checkUpdate(t *testing.T, name string, got, want UpdateData) {
+	t.Helper()
+	for _, f := range []struct {
+		field     string
+		got, want []byte
+	}{
+		{"project", got.ProjectTxID, want.ProjectTxID},
+		{"hash", got.UpdateExecutableHash, want.UpdateExecutableHash},
+		{"url", got.UpdateIPFSUrl, want.UpdateIPFSUrl},
+		{"device", got.ForDeviceName, want.ForDeviceName},
+		{"signature", got.Signature, want.Signature},
+		{"patch hash", got.PatchHash, want.PatchHash},
+		{"patch url", got.PatchIPFSUrl, want.PatchIPFSUrl},
+	} {
+		if !bytes.Equal(f.got, f.want) {
+			t.Errorf("%s: %s = %q, want %q", name, f.field, f.got, f.want)
+		}
+	}
+	if got.UpdateVersion != want.UpdateVersion {
+		t.Errorf("%s: version = %s, want %s", name, got.UpdateVersion, want.UpdateVersion)
+	}
+	if got.Channel != want.Channel {
+		t.Errorf("%s: channel = %d, want %d", name, got.Channel, want.Channel)
+	}
+	if got.SuccessCount != want.SuccessCount {
+		t.Errorf("%s: success count = %d, want %d", name, got.SuccessCount, want.SuccessCount)
+	}
+	if got.BaseUpdate != want.BaseUpdate {
+		t.Errorf("%s: base update = %s, want %s", name, got.BaseUpdate, want.BaseUpdate)
+	}
+	if got.UpdateWindow != want.UpdateWindow {
+		t.Errorf("%s: window = %+v, want %+v", name, got.UpdateWindow, want.UpdateWindow)
+	}
+}
+
+func TestProjectRecord(t *testing.T) {
+	owner := codec.MustAddressBech32(tconsts.HRP, testOwner)
+	tests := []struct {
+		name string
+		v    []byte
+		want ProjectData
+		err  bool
+	}{
+		{
+			name: "legacy",
+			v:    legacyProjectRecord("thermostat", "firmware", owner, "logo"),
+			want: ProjectData{ProjectName: []byte("thermostat"), ProjectDescription: []byte("firmware"), ProjectOwner: testOwner, Logo: []byte("logo")},
+		},
+		{
+			name: "legacy full width",
+			v: legacyProjectRecord(
+				strings.Repeat("n", 32),
+				strings.Repeat("d", 100),
+				owner,
+				strings.Repeat("l", 100),
+			),
+			want: ProjectData{
+				ProjectName:        bytes.Repeat([]byte("n"), 32),
+				ProjectDescription: bytes.Repeat([]byte("d"), 100),
+				ProjectOwner:       testOwner,
+				Logo:               bytes.Repeat([]byte("l"), 100),
+			},
+		},
+		{
+			name: "v1 long description",
+			v: mustEncodeProject(t, ProjectData{
+				ProjectName:        []byte("thermostat"),
+				ProjectDescription: bytes.Repeat([]byte("d"), MaxProjectDescriptionLen),
+				ProjectOwner:       testOwner,
+				Logo:               bytes.Repeat([]byte("l"), MaxProjectLogoLen),
+			}),
+			want: ProjectData{
+				ProjectName:        []byte("thermostat"),
+				ProjectDescription: bytes.Repeat([]byte("d"), MaxProjectDescriptionLen),
+				ProjectOwner:       testOwner,
+				Logo:               bytes.Repeat([]byte("l"), MaxProjectLogoLen),
+			},
+		},
+		{
+			name: "v1 without logo",
+			v:    mustEncodeProject(t, ProjectData{ProjectName: []byte("thermostat"), ProjectOwner: testOwner}),
+			want: ProjectData{ProjectName: []byte("thermostat"), ProjectOwner: testOwner},
+		},
+		{
+			name: "unknown version",
+			v:    append([]byte{projectRecordV1 + 1}, mustEncodeProject(t, ProjectData{ProjectName: []byte("thermostat"), ProjectOwner: testOwner})[1:]...),
+			err:  true,
+		},
+		{
+			name: "truncated",
+			v:    mustEncodeProject(t, ProjectData{ProjectName: []byte("thermostat"), ProjectOwner: testOwner})[:10],
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		got, err := decodeProject(tt.v)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: decoded %+v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.ProjectOwner != tt.want.ProjectOwner ||
+			!bytes.Equal(got.ProjectName, tt.want.ProjectName) ||
+			!bytes.Equal(got.ProjectDescription, tt.want.ProjectDescription) ||
+			!bytes.Equal(got.Logo, tt.want.Logo) {
+			t.Errorf("%s: decoded %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeProjectTooLong(t *testing.T) {
+	if _, err := encodeProject(ProjectData{
+		ProjectName:        []byte("thermostat"),
+		ProjectDescription: bytes.Repeat([]byte("d"), maxProjectLen),
+		ProjectOwner:       testOwner,
+	}); err == nil {
+		t.Fatal("encoded a project larger than the record")
+	}
+}
+
+func TestUpdateRecord(t *testing.T) {
+	current := UpdateData{
+		ProjectTxID:          []byte(ids.GenerateTestID().String()),
+		UpdateExecutableHash: []byte("sha256:00"),
+		UpdateIPFSUrl:        []byte("https://ipfs.io/ipfs/cid"),
+		ForDeviceName:        []byte("thermostat"),
+		UpdateVersion:        SemVer{Major: 1, Minor: 2, PreRelease: "rc.1"},
+		Channel:              2,
+		Signature:            bytes.Repeat([]byte{1}, 64),
+		UpdatePatch: UpdatePatch{
+			BaseUpdate:   ids.GenerateTestID(),
+			PatchHash:    []byte("sha256:01"),
+			PatchIPFSUrl: []byte("https://ipfs.io/ipfs/patch"),
+		},
+		UpdateWindow: UpdateWindow{NotBefore: 10, ExpiresAt: 20},
+	}
+	encoded, err := encodeUpdate(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v1 := current
+	v1.Signature = nil
+	v1.UpdatePatch = UpdatePatch{}
+	v1.UpdateWindow = UpdateWindow{}
+	v2 := current
+	v2.UpdatePatch = UpdatePatch{}
+	v2.UpdateWindow = UpdateWindow{}
+	v3 := current
+	v3.UpdateWindow = UpdateWindow{}
+
+	tests := []struct {
+		name string
+		v    []byte
+		want UpdateData
+		err  bool
+	}{
+		{name: "current", v: encoded, want: current},
+		{name: "v1", v: versionedUpdateRecord(t, updateRecordV1, v1), want: v1},
+		{name: "v2", v: versionedUpdateRecord(t, updateRecordV2, v2), want: v2},
+		{name: "v3", v: versionedUpdateRecord(t, updateRecordV3, v3), want: v3},
+		{
+			name: "legacy",
+			v:    legacyUpdateRecord("project", "hash", "url", "thermostat", 7, 3),
+			want: UpdateData{
+				ProjectTxID:          []byte("project"),
+				UpdateExecutableHash: []byte("hash"),
+				UpdateIPFSUrl:        []byte("url"),
+				ForDeviceName:        []byte("thermostat"),
+				UpdateVersion:        LegacyVersion(7),
+				SuccessCount:         3,
+			},
+		},
+		{
+			name: "legacy full width",
+			v: legacyUpdateRecord(
+				strings.Repeat("p", 100),
+				strings.Repeat("h", 100),
+				strings.Repeat("u", 100),
+				strings.Repeat("d", 100),
+				1, 0,
+			),
+			want: UpdateData{
+				ProjectTxID:          bytes.Repeat([]byte("p"), 100),
+				UpdateExecutableHash: bytes.Repeat([]byte("h"), 100),
+				UpdateIPFSUrl:        bytes.Repeat([]byte("u"), 100),
+				ForDeviceName:        bytes.Repeat([]byte("d"), 100),
+				UpdateVersion:        LegacyVersion(1),
+			},
+		},
+		// Only the fixed width layout predates schema versions
+		{name: "legacy with trailing bytes", v: append(legacyUpdateRecord("project", "hash", "url", "thermostat", 1, 0), 0), err: true},
+		{name: "unknown version", v: append([]byte{updateRecordV4 + 1}, encoded[1:]...), err: true},
+		{name: "truncated", v: encoded[:len(encoded)-1], err: true},
+	}
+	for _, tt := range tests {
+		got, err := decodeUpdate(tt.v)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: decoded %+v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkUpdate(t, tt.name, got, tt.want)
+	}
+}
+
+func TestLegacyRecordLen(t *testing.T) {
+	if legacyProjectLen != 732 {
+		t.Errorf("legacyProjectLen = %d, want 732", legacyProjectLen)
+	}
+	if legacyUpdateLen != 402 {
+		t.Errorf("legacyUpdateLen = %d, want 402", legacyUpdateLen)
+	}
+}
+
+func TestRecordKeys(t *testing.T) {
+	id := ids.GenerateTestID()
+	for _, tt := range []struct {
+		name   string
+		k      []byte
+		chunks uint16
+	}{
+		{"project", ProjectKey(id), ProjectChunks},
+		{"legacy project", LegacyProjectKey(id), LegacyProjectChunks},
+		{"update", UpdateKey(id), UpdateChunks},
+		{"legacy update", LegacyUpdateKey(id), LegacyUpdateChunks},
+	} {
+		if got := binary.BigEndian.Uint16(tt.k[len(tt.k)-consts.Uint16Len:]); got != tt.chunks {
+			t.Errorf("%s key chunks = %d, want %d", tt.name, got, tt.chunks)
+		}
+	}
+	if int(ProjectChunks)*chunkSize < maxProjectLen {
+		t.Errorf("%d project chunks hold less than %d bytes", ProjectChunks, maxProjectLen)
+	}
+	if int(UpdateChunks)*chunkSize < maxUpdateLen {
+		t.Errorf("%d update chunks hold less than %d bytes", UpdateChunks, maxUpdateLen)
+	}
+}
+
+func TestLegacyProjectKeyMigrates(t *testing.T) {
+	ctx := context.Background()
+	mu := testState{}
+	project := ids.GenerateTestID()
+	owner := codec.MustAddressBech32(tconsts.HRP, testOwner)
+	if err := mu.Insert(ctx, LegacyProjectKey(project), legacyProjectRecord("thermostat", "firmware", owner, "logo")); err != nil {
+		t.Fatal(err)
+	}
+
+	exists, data, err := GetProject(ctx, mu, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists || data.ProjectOwner != testOwner {
+		t.Fatalf("GetProject = %t, %+v", exists, data)
+	}
+
+	newOwner := codec.CreateAddress(0, ids.GenerateTestID())
+	if err := SetProjectOwner(ctx, mu, project, newOwner); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mu.GetValue(ctx, LegacyProjectKey(project)); err != database.ErrNotFound {
+		t.Fatalf("legacy record left behind: %v", err)
+	}
+	_, data, err = GetProject(ctx, mu, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.ProjectOwner != newOwner || !bytes.Equal(data.ProjectDescription, []byte("firmware")) {
+		t.Fatalf("migrated project = %+v", data)
+	}
+}
+
+func TestLegacyUpdateKeyMigrates(t *testing.T) {
+	ctx := context.Background()
+	mu := testState{}
+	update := ids.GenerateTestID()
+	if err := mu.Insert(ctx, LegacyUpdateKey(update), legacyUpdateRecord("project", "hash", "url", "thermostat", 3, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetUpdateChannel(ctx, mu, update, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mu.GetValue(ctx, LegacyUpdateKey(update)); err != database.ErrNotFound {
+		t.Fatalf("legacy record left behind: %v", err)
+	}
+	exists, data, err := GetUpdate(ctx, mu, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("update not found")
+	}
+	checkUpdate(t, "migrated", data, UpdateData{
+		ProjectTxID:          []byte("project"),
+		UpdateExecutableHash: []byte("hash"),
+		UpdateIPFSUrl:        []byte("url"),
+		ForDeviceName:        []byte("thermostat"),
+		UpdateVersion:        LegacyVersion(3),
+		Channel:              1,
+		SuccessCount:         4,
+	})
+}
+
+func mustEncodeProject(t *testing.T, data ProjectData) []byte {
+	t.Helper()
+	v, err := encodeProject(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// MaxRolloutPercentage releases an update to the whole fleet.
+const MaxRolloutPercentage uint8 = 100
+
+// RolloutBucket places [device] in one of [MaxRolloutPercentage] buckets for
+// [update]. The bucket only depends on the two IDs, so a device always gets
+// the same answer and raising the percentage never drops a device that was
+// already eligible. Mixing in [update] spreads the early adopters differently
+// for every release.
+func RolloutBucket(device ids.ID, update ids.ID) uint8 {
+	h := sha256.New()
+	h.Write(device[:])
+	h.Write(update[:])
+	sum := h.Sum(nil)
+	return uint8(binary.BigEndian.Uint64(sum) % uint64(MaxRolloutPercentage))
+}
+
+// InRollout reports whether [device] should receive [update] when it is
+// rolled out to [percentage] of the fleet.
+func InRollout(device ids.ID, update ids.ID, percentage uint8) bool {
+	return RolloutBucket(device, update) < percentage
+}
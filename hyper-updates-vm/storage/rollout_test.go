@@ -0,0 +1,135 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// testState is an in-memory [state.Mutable].
+type testState map[string][]byte
+
+func (s testState) GetValue(_ context.Context, key []byte) ([]byte, error) {
+	v, ok := s[string(key)]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s testState) Insert(_ context.Context, key []byte, value []byte) error {
+	s[string(key)] = value
+	return nil
+}
+
+func (s testState) Remove(_ context.Context, key []byte) error {
+	delete(s, string(key))
+	return nil
+}
+
+func TestRolloutBucket(t *testing.T) {
+	var sequential [2]ids.ID
+	for i := range sequential[0] {
+		sequential[0][i] = byte(i)
+		sequential[1][i] = byte(32 + i)
+	}
+	// sha256(device|update), first 8 bytes big endian, mod 100
+	tests := []struct {
+		device ids.ID
+		update ids.ID
+		bucket uint8
+	}{
+		{ids.Empty, ids.Empty, 24},
+		{ids.ID{1}, ids.ID{2}, 32},
+		{ids.ID{2}, ids.ID{1}, 59},
+		{ids.ID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, ids.Empty, 99},
+		{sequential[0], sequential[1], 6},
+	}
+	for _, tt := range tests {
+		if got := RolloutBucket(tt.device, tt.update); got != tt.bucket {
+			t.Errorf("RolloutBucket(%s, %s) = %d, want %d", tt.device, tt.update, got, tt.bucket)
+		}
+	}
+}
+
+func TestInRolloutOnlyGrows(t *testing.T) {
+	update := ids.GenerateTestID()
+	devices := make([]ids.ID, 1000)
+	for i := range devices {
+		devices[i] = ids.GenerateTestID()
+	}
+	eligible := 0
+	for percentage := uint8(0); percentage <= MaxRolloutPercentage; percentage++ {
+		count := 0
+		for _, device := range devices {
+			in := InRollout(device, update, percentage)
+			if percentage > 0 && InRollout(device, update, percentage-1) && !in {
+				t.Fatalf("device %s dropped out of the rollout at %d%%", device, percentage)
+			}
+			if in {
+				count++
+			}
+		}
+		if count < eligible {
+			t.Fatalf("%d devices eligible at %d%%, %d before", count, percentage, eligible)
+		}
+		eligible = count
+	}
+	if eligible != len(devices) {
+		t.Fatalf("%d of %d devices eligible at %d%%", eligible, len(devices), MaxRolloutPercentage)
+	}
+	if InRollout(devices[0], update, 0) {
+		t.Fatal("device eligible at 0%")
+	}
+}
+
+func TestMissingRolloutIsFullRollout(t *testing.T) {
+	ctx := context.Background()
+	mu := testState{}
+	update := ids.GenerateTestID()
+	if err := SetUpdate(
+		ctx,
+		mu,
+		update,
+		[]byte(ids.GenerateTestID().String()),
+		[]byte("sha256:00"),
+		[]byte("https://ipfs.io/ipfs/cid"),
+		[]byte("thermostat"),
+		SemVer{Major: 1},
+		0,
+		nil,
+		UpdatePatch{},
+		UpdateWindow{},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	exists, data, err := GetUpdate(ctx, mu, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Fatal("update not found")
+	}
+	if data.RolloutPercentage != MaxRolloutPercentage {
+		t.Fatalf("rollout = %d, want %d", data.RolloutPercentage, MaxRolloutPercentage)
+	}
+
+	if err := SetRollout(ctx, mu, update, 25); err != nil {
+		t.Fatal(err)
+	}
+	if _, data, err = GetUpdate(ctx, mu, update); err != nil {
+		t.Fatal(err)
+	}
+	if data.RolloutPercentage != 25 {
+		t.Fatalf("rollout = %d, want 25", data.RolloutPercentage)
+	}
+	if err := SetRollout(ctx, mu, update, MaxRolloutPercentage+1); err != ErrRolloutPercentageInvalid {
+		t.Fatalf("SetRollout(%d) error = %v, want %v", MaxRolloutPercentage+1, err, ErrRolloutPercentageInvalid)
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+const (
+	// MaxSemVerLabelLen bounds the pre-release and build metadata so a version
+	// always fits in the fixed update record.
+	MaxSemVerLabelLen = 64
+
+	// Fixed part of an encoded version: major|minor|patch|preLen|buildLen
+	semVerFixedLen = 3*consts.Uint32Len + 2*consts.Uint8Len
+	MaxSemVerLen   = semVerFixedLen + 2*MaxSemVerLabelLen
+)
+
+// SemVer is a semantic version (https://semver.org/spec/v2.0.0.html).
+type SemVer struct {
+	Major      uint32 `json:"major"`
+	Minor      uint32 `json:"minor"`
+	Patch      uint32 `json:"patch"`
+	PreRelease string `json:"pre_release"`
+	Build      string `json:"build"`
+}
+
+// LegacyVersion maps a version stored before semantic versions were
+// introduced (a single byte) to [version].0.0.
+func LegacyVersion(version uint8) SemVer {
+	return SemVer{Major: uint32(version)}
+}
+
+// ParseSemVer parses strings of the form
+// MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD], e.g. 2.10.1-rc.3.
+func ParseSemVer(s string) (SemVer, error) {
+	var v SemVer
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		v.Build = s[i+1:]
+		if !validIdentifiers(v.Build, false) {
+			return SemVer{}, ErrInvalidSemVer
+		}
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		v.PreRelease = s[i+1:]
+		if !validIdentifiers(v.PreRelease, true) {
+			return SemVer{}, ErrInvalidSemVer
+		}
+		s = s[:i]
+	}
+	if len(v.PreRelease) > MaxSemVerLabelLen || len(v.Build) > MaxSemVerLabelLen {
+		return SemVer{}, ErrInvalidSemVer
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) != 3 {
+		return SemVer{}, ErrInvalidSemVer
+	}
+	nums := [3]*uint32{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		if !isNumeric(part) || (len(part) > 1 && part[0] == '0') {
+			return SemVer{}, ErrInvalidSemVer
+		}
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return SemVer{}, ErrInvalidSemVer
+		}
+		*nums[i] = uint32(n)
+	}
+	return v, nil
+}
+
+func (v SemVer) String() string {
+	s := strconv.FormatUint(uint64(v.Major), 10) + "." +
+		strconv.FormatUint(uint64(v.Minor), 10) + "." +
+		strconv.FormatUint(uint64(v.Patch), 10)
+	if len(v.PreRelease) > 0 {
+		s += "-" + v.PreRelease
+	}
+	if len(v.Build) > 0 {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// CompareSemVer returns -1, 0 or 1 depending on whether [a] has lower, equal
+// or higher precedence than [b]. Build metadata is ignored, as required by
+// the spec.
+func CompareSemVer(a, b SemVer) int {
+	if c := compareUint(a.Major, b.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Minor, b.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(a.Patch, b.Patch); c != 0 {
+		return c
+	}
+	// A pre-release has lower precedence than the associated normal version
+	switch {
+	case a.PreRelease == b.PreRelease:
+		return 0
+	case len(a.PreRelease) == 0:
+		return 1
+	case len(b.PreRelease) == 0:
+		return -1
+	}
+	as, bs := strings.Split(a.PreRelease, "."), strings.Split(b.PreRelease, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint32(len(as)), uint32(len(bs)))
+}
+
+func compareIdentifier(a, b string) int {
+	an, bn := isNumeric(a), isNumeric(b)
+	switch {
+	case an && bn:
+		// Identifiers have no leading zeros, so the longer one is larger
+		if len(a) != len(b) {
+			return compareUint(uint32(len(a)), uint32(len(b)))
+		}
+		return strings.Compare(a, b)
+	case an:
+		// Numeric identifiers always have lower precedence
+		return -1
+	case bn:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func compareUint(a, b uint32) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func isNumeric(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func validIdentifiers(s string, preRelease bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if len(id) == 0 {
+			return false
+		}
+		for i := 0; i < len(id); i++ {
+			c := id[i]
+			if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && c != '-' {
+				return false
+			}
+		}
+		// Numeric pre-release identifiers must not include leading zeros
+		if preRelease && len(id) > 1 && id[0] == '0' && isNumeric(id) {
+			return false
+		}
+	}
+	return true
+}
+
+func semVerLen(v SemVer) int {
+	return semVerFixedLen + len(v.PreRelease) + len(v.Build)
+}
+
+// encodeSemVer writes [v] into [b], which must have at least [semVerLen]
+// bytes.
+func encodeSemVer(b []byte, v SemVer) {
+	binary.BigEndian.PutUint32(b, v.Major)
+	binary.BigEndian.PutUint32(b[consts.Uint32Len:], v.Minor)
+	binary.BigEndian.PutUint32(b[2*consts.Uint32Len:], v.Patch)
+	b[3*consts.Uint32Len] = uint8(len(v.PreRelease))
+	b[3*consts.Uint32Len+1] = uint8(len(v.Build))
+	copy(b[semVerFixedLen:], v.PreRelease)
+	copy(b[semVerFixedLen+len(v.PreRelease):], v.Build)
+}
+
+func decodeSemVer(b []byte) (SemVer, error) {
+	if len(b) < semVerFixedLen {
+		return SemVer{}, ErrInvalidSemVer
+	}
+	preLen := int(b[3*consts.Uint32Len])
+	buildLen := int(b[3*consts.Uint32Len+1])
+	if len(b) < semVerFixedLen+preLen+buildLen {
+		return SemVer{}, ErrInvalidSemVer
+	}
+	return SemVer{
+		Major:      binary.BigEndian.Uint32(b),
+		Minor:      binary.BigEndian.Uint32(b[consts.Uint32Len:]),
+		Patch:      binary.BigEndian.Uint32(b[2*consts.Uint32Len:]),
+		PreRelease: string(b[semVerFixedLen : semVerFixedLen+preLen]),
+		Build:      string(b[semVerFixedLen+preLen : semVerFixedLen+preLen+buildLen]),
+	}, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		s    string
+		want SemVer
+		err  error
+	}{
+		{s: "0.0.0", want: SemVer{}},
+		{s: "2.10.1", want: SemVer{Major: 2, Minor: 10, Patch: 1}},
+		{s: "1.0.0-rc.3", want: SemVer{Major: 1, PreRelease: "rc.3"}},
+		{s: "1.0.0-alpha-1", want: SemVer{Major: 1, PreRelease: "alpha-1"}},
+		{s: "1.0.0+20230101.sha-5114f85", want: SemVer{Major: 1, Build: "20230101.sha-5114f85"}},
+		{s: "1.0.0-beta.2+exp.01", want: SemVer{Major: 1, PreRelease: "beta.2", Build: "exp.01"}},
+		{s: "4294967295.0.0", want: SemVer{Major: 4294967295}},
+
+		{s: "", err: ErrInvalidSemVer},
+		{s: "1", err: ErrInvalidSemVer},
+		{s: "1.0", err: ErrInvalidSemVer},
+		{s: "1.0.0.0", err: ErrInvalidSemVer},
+		{s: "v1.0.0", err: ErrInvalidSemVer},
+		{s: "1.-1.0", err: ErrInvalidSemVer},
+		{s: "4294967296.0.0", err: ErrInvalidSemVer},
+		{s: "01.0.0", err: ErrInvalidSemVer},
+		{s: "1.00.0", err: ErrInvalidSemVer},
+		{s: "1.0.00", err: ErrInvalidSemVer},
+		{s: "1.0.0-01", err: ErrInvalidSemVer},
+		{s: "1.0.0-rc.01", err: ErrInvalidSemVer},
+		{s: "1.0.0-", err: ErrInvalidSemVer},
+		{s: "1.0.0-rc..1", err: ErrInvalidSemVer},
+		{s: "1.0.0-rc_1", err: ErrInvalidSemVer},
+		{s: "1.0.0+", err: ErrInvalidSemVer},
+		{s: "1.0.0+build!", err: ErrInvalidSemVer},
+		{s: "1.0.0-" + strings.Repeat("a", MaxSemVerLabelLen+1), err: ErrInvalidSemVer},
+		{s: "1.0.0+" + strings.Repeat("a", MaxSemVerLabelLen+1), err: ErrInvalidSemVer},
+	}
+	for _, tt := range tests {
+		got, err := ParseSemVer(tt.s)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseSemVer(%q) error = %v, want %v", tt.s, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSemVer(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+		if tt.err == nil && got.String() != tt.s {
+			t.Errorf("ParseSemVer(%q).String() = %q", tt.s, got.String())
+		}
+	}
+}
+
+func TestParseSemVerLeadingZeros(t *testing.T) {
+	// Leading zeros are only invalid in numeric identifiers, build metadata
+	// and alphanumeric pre-release identifiers may start with 0
+	for _, s := range []string{"1.0.0-0", "1.0.0-0a", "1.0.0-rc.0", "1.0.0+001"} {
+		if _, err := ParseSemVer(s); err != nil {
+			t.Errorf("ParseSemVer(%q) error = %v", s, err)
+		}
+	}
+}
+
+func TestCompareSemVer(t *testing.T) {
+	// Ordered by increasing precedence, from https://semver.org/#spec-item-11
+	ordered := []string{
+		"0.9.9",
+		"1.0.0-0",
+		"1.0.0-1",
+		"1.0.0-2",
+		"1.0.0-10",
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"1.10.0",
+		"2.0.0",
+	}
+	for i, as := range ordered {
+		a, err := ParseSemVer(as)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, bs := range ordered {
+			b, err := ParseSemVer(bs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := compareUint(uint32(i), uint32(j))
+			if got := CompareSemVer(a, b); got != want {
+				t.Errorf("CompareSemVer(%s, %s) = %d, want %d", as, bs, got, want)
+			}
+		}
+	}
+}
+
+func TestCompareSemVerIgnoresBuild(t *testing.T) {
+	tests := []struct{ a, b string }{
+		{"1.0.0+a", "1.0.0+b"},
+		{"1.0.0", "1.0.0+20230101"},
+		{"1.0.0-rc.1+linux", "1.0.0-rc.1+darwin"},
+	}
+	for _, tt := range tests {
+		a, err := ParseSemVer(tt.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseSemVer(tt.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c := CompareSemVer(a, b); c != 0 {
+			t.Errorf("CompareSemVer(%s, %s) = %d, want 0", tt.a, tt.b, c)
+		}
+	}
+}
+
+func TestSemVerEncoding(t *testing.T) {
+	for _, s := range []string{"0.0.0", "1.2.3", "1.0.0-rc.1", "1.0.0+build.5", "3.1.4-beta.2+exp"} {
+		v, err := ParseSemVer(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, semVerLen(v))
+		encodeSemVer(b, v)
+		got, err := decodeSemVer(b)
+		if err != nil {
+			t.Fatalf("decodeSemVer(%s) error = %v", s, err)
+		}
+		if got != v {
+			t.Errorf("decodeSemVer(%s) = %+v, want %+v", s, got, v)
+		}
+		if _, err := decodeSemVer(b[:len(b)-1]); err == nil {
+			t.Errorf("decodeSemVer(%s) accepted a truncated version", s)
+		}
+	}
+	if _, err := decodeSemVer(nil); !errors.Is(err, ErrInvalidSemVer) {
+		t.Errorf("decodeSemVer(nil) error = %v, want %v", err, ErrInvalidSemVer)
+	}
+}
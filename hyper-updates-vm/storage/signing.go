@@ -0,0 +1,50 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
+)
+
+// updateSigningDomain keeps update signatures from being valid for anything
+// else the vendor key may sign.
+var updateSigningDomain = []byte("hyper-updates/update-signature/v1")
+
+// UpdateSigningMessage is the message a vendor signs to vouch for a build. It
+// binds the executable [digest] (see [Digest.Bytes]), or the [Manifest.Digest]
+// of a multi-artifact release, to the release it is published as, the version
+// is signed in its canonical form.
+func UpdateSigningMessage(project ids.ID, version SemVer, device []byte, digest []byte) []byte {
+	v := version.String()
+	size := codec.BytesLen(updateSigningDomain) +
+		consts.IDLen +
+		codec.StringLen(v) +
+		codec.BytesLen(device) +
+		codec.BytesLen(digest)
+	p := codec.NewWriter(size, size)
+	p.PackBytes(updateSigningDomain)
+	p.PackID(project)
+	p.PackString(v)
+	p.PackBytes(device)
+	p.PackBytes(digest)
+	return p.Bytes()
+}
+
+// VerifyUpdateSignature returns the key in [keys] that produced [signature]
+// over [msg].
+func VerifyUpdateSignature(keys []ed25519.PublicKey, msg []byte, signature []byte) (ed25519.PublicKey, bool) {
+	if len(signature) != ed25519.SignatureLen {
+		return ed25519.EmptyPublicKey, false
+	}
+	sig := ed25519.Signature(signature)
+	for _, key := range keys {
+		if ed25519.Verify(msg, key, sig) {
+			return key, true
+		}
+	}
+	return ed25519.EmptyPublicKey, false
+}
@@ -19,7 +19,9 @@ import (
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
 	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto/ed25519"
 	"github.com/ava-labs/hypersdk/state"
+	"github.com/ava-labs/hypersdk/utils"
 )
 
 type ReadState func(context.Context, [][]byte) ([][]byte, []error)
@@ -27,6 +29,15 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // Metadata
 // 0x0/ (tx)
 //   -> [txID] => timestamp
+// 0x1/ (project devices)
+//   -> [project|device] => nil
+// 0x2/ (project history)
+//   -> [project|timestamp|txID] => descriptionLen|description|logoLen|logo
+// 0x3/ (owner projects)
+//   -> [owner|project] => nil
+//      (entries are kept when ownership moves, readers check the owner)
+// 0x4/ (project updates)
+//   -> [project|timestamp|update] => nil
 //
 // State
 // 0x0/ (balance)
@@ -42,23 +53,104 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 // 0x6/ (hypersdk-fee)
 // 0x7/ (hypersdk-incoming warp)
 // 0x8/ (hypersdk-outgoing warp)
+// 0x9/ (projects)
+//   -> [txID] => schemaVersion|nameLen|name|descriptionLen|description|owner|logoLen|logo
+//      (legacy records are name|description|owner|logo, zero padded to a
+//      fixed width, and are keyed with [LegacyProjectChunks])
+// 0xA/ (updates)
+//   -> [txID] => schemaVersion|projectLen|project|hashLen|hash|urlLen|url|deviceLen|device|versionLen|version|channel|signatureLen|signature|baseUpdate|patchHashLen|patchHash|patchUrlLen|patchUrl|notBefore|expiresAt
+//      (version 1 records end at channel, version 2 records at signature,
+//      version 3 records at patchUrl)
+//      (legacy records are project|hash|url|device|version|successCount,
+//      zero padded to a fixed width, their version byte is read as
+//      version.0.0 on the stable channel; they are keyed with
+//      [LegacyUpdateChunks])
+// 0xB/ (project maintainers)
+//   -> [project] => count|(address|roles)*
+// 0xC/ (latest update)
+//   -> [project|device|channel] => update|version
+//      (the stable channel omits the channel byte)
+//      (update is empty once it moved to another channel, version still
+//      bounds the next release of the channel)
+//      (legacy records hold a single version byte)
+// 0xD/ (update revocations)
+//   -> [update] => reason|timestamp|noteLen|note
+// 0xE/ (update install results)
+//   -> [update] => successCount|failureCount
+// 0xF/ (devices)
+//   -> [device] => project|publicKey|status|registeredAt|updatedAt|modelLen|model|version
+// 0x10/ (update reports)
+//   -> [update|device] => status|errorCode
+// 0x11/ (update rollouts)
+//   -> [update] => percentage
+//      (updates without a rollout are served to the whole fleet)
+// 0x12/ (pending project owners)
+//   -> [project] => owner
+// 0x13/ (project signing keys)
+//   -> [project] => count|publicKey*
+// 0x14/ (update manifests)
+//   -> [update] => schemaVersion|count|(type|partitionLen|partition|offset|size|digestLen|digest|urlLen|url)*
+//      (single image updates have no manifest)
+// 0x15/ (update constraints)
+//   -> [update] => schemaVersion|count|(boardLen|board)*|minHardwareRevision|minSourceLen|minSource|maxSourceLen|maxSource|passThrough
+//      (unconstrained updates have no record, unset versions are empty)
+// 0x16/ (project approval thresholds)
+//   -> [project] => threshold
+//      (projects without a threshold release updates on creation)
+// 0x17/ (update approvals)
+//   -> [update] => schemaVersion|threshold|author|count|(approver|timestamp)*|releasedAt
+//      (updates created without a threshold have no record)
+// 0x18/ (project license assets)
+//   -> [project] => asset
+// 0x19/ (update licenses)
+//   -> [update] => asset|minBalance
+//      (updates served without a license have no record)
+// 0x1A/ (update origins)
+//   -> [update] => sourceChainID|sourceTxID|sourceProject
+//      (only imported updates have a record)
+// 0x1B/ (imported updates)
+//   -> [sourceChainID|sourceTxID] => update
+// 0x1C/ (project import sources)
+//   -> [project] => count|(sourceChainID|sourceProject)*
 
 const (
 	// metaDB
-	txPrefix = 0x0
+	txPrefix             = 0x0
+	projectDevicePrefix  = 0x1
+	projectHistoryPrefix = 0x2
+	ownerProjectPrefix   = 0x3
+	projectUpdatePrefix  = 0x4
 
 	// stateDB
-	balancePrefix      = 0x0
-	assetPrefix        = 0x1
-	orderPrefix        = 0x2
-	loanPrefix         = 0x3
-	heightPrefix       = 0x4
-	timestampPrefix    = 0x5
-	feePrefix          = 0x6
-	incomingWarpPrefix = 0x7
-	outgoingWarpPrefix = 0x8
-	projectPrefix      = 0x9
-	updatePrefix       = 0xA
+	balancePrefix       = 0x0
+	assetPrefix         = 0x1
+	orderPrefix         = 0x2
+	loanPrefix          = 0x3
+	heightPrefix        = 0x4
+	timestampPrefix     = 0x5
+	feePrefix           = 0x6
+	incomingWarpPrefix  = 0x7
+	outgoingWarpPrefix  = 0x8
+	projectPrefix       = 0x9
+	updatePrefix        = 0xA
+	maintainersPrefix   = 0xB
+	latestUpdatePrefix  = 0xC
+	revocationPrefix    = 0xD
+	updateResultsPrefix = 0xE
+	devicePrefix        = 0xF
+	updateReportPrefix  = 0x10
+	rolloutPrefix       = 0x11
+	pendingOwnerPrefix  = 0x12
+	signingKeysPrefix   = 0x13
+	manifestPrefix      = 0x14
+	constraintsPrefix   = 0x15
+	thresholdPrefix     = 0x16
+	approvalsPrefix     = 0x17
+	licenseAssetPrefix  = 0x18
+	updateLicensePrefix = 0x19
+	updateOriginPrefix  = 0x1A
+	importedPrefix      = 0x1B
+	importSourcesPrefix = 0x1C
 )
 
 const (
@@ -67,17 +159,74 @@ const (
 	OrderChunks   uint16 = 2
 	LoanChunks    uint16 = 1
 
+	// MaxProjectNameLen, MaxProjectDescriptionLen and MaxProjectLogoLen
+	// bound the fields of a project record.
+	MaxProjectNameLen        = 64
+	MaxProjectDescriptionLen = 1024
+	MaxProjectLogoLen        = 256
+
+	// Field widths of legacy project records
 	ProjectNameChunks        uint16 = 32
 	ProjectLogoChunks        uint16 = 100
 	ProjectDescriptionChunks uint16 = 100
-	ProjectOwnerChunks       uint16 = 500
+	ProjectOwnerChunks       uint16 = 500 // bech32 owner of legacy records
+
+	// LegacyProjectChunks and LegacyUpdateChunks are the chunk counts of the
+	// keys records were stored under before [ProjectChunks] and
+	// [UpdateChunks]. Records are moved to the current key the next time
+	// they are written.
+	LegacyProjectChunks uint16 = 100
+	LegacyUpdateChunks  uint16 = 100
 
+	// Field widths of legacy update records
 	ProjectTxIDChunks             = 100
 	UpdateExecutableHashChunks    = 100
 	UpdateExecutableIPFSUrlChunks = 100
 	ForDeviceNameChunks           = 100
 	UpdateVersionUnitsChunks      = 1
 	SuccessCountUnitsChunks       = 1
+
+	// MaxProjectMaintainers bounds the maintainer set so it always fits in
+	// [MaintainersChunks].
+	MaxProjectMaintainers        = 32
+	MaintainersChunks     uint16 = 18 // ceil((2 + 32*(33+1)) / 64)
+
+	LatestUpdateChunks uint16 = 3 // ceil((32 + MaxSemVerLen) / 64)
+
+	MaxRevocationNoteLen        = 256
+	RevocationChunks     uint16 = 5 // ceil((1 + 8 + 2 + 256) / 64)
+
+	UpdateResultsChunks uint16 = 1
+
+	RolloutChunks uint16 = 1
+
+	PendingOwnerChunks uint16 = 1
+
+	// MaxProjectSigningKeys bounds the signing keys of a project so they
+	// always fit in [SigningKeysChunks].
+	MaxProjectSigningKeys        = 8
+	SigningKeysChunks     uint16 = 5 // ceil((2 + 8*32) / 64)
+
+	MaxDeviceModelLen        = 64
+	DeviceChunks      uint16 = 5 // ceil((32 + 32 + 1 + 8 + 8 + 1 + 64 + MaxSemVerLen) / 64)
+
+	UpdateReportChunks uint16 = 1
+
+	// MaxApprovalThreshold bounds the approvals of an update so they always
+	// fit in [ApprovalsChunks].
+	MaxApprovalThreshold        = 8
+	ThresholdChunks      uint16 = 1
+
+	LicenseAssetChunks  uint16 = 1
+	UpdateLicenseChunks uint16 = 1
+
+	UpdateOriginChunks   uint16 = 2
+	ImportedUpdateChunks uint16 = 1
+
+	// MaxProjectImportSources bounds the import sources of a project so they
+	// always fit in [ImportSourcesChunks].
+	MaxProjectImportSources        = 8
+	ImportSourcesChunks     uint16 = 9 // ceil((2 + 8*64) / 64)
 )
 
 var (
@@ -622,10 +771,20 @@ func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
 
 // [projectPrefix] + [address]
 func ProjectKey(project ids.ID) (k []byte) {
+	return projectKey(project, ProjectChunks)
+}
+
+// LegacyProjectKey is where projects written before [ProjectChunks] are
+// stored. Callers reading a project must include it in their state keys.
+func LegacyProjectKey(project ids.ID) (k []byte) {
+	return projectKey(project, LegacyProjectChunks)
+}
+
+func projectKey(project ids.ID, chunks uint16) (k []byte) {
 	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
 	k[0] = projectPrefix
 	copy(k[1:], project[:])
-	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ProjectDescriptionChunks)
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], chunks)
 	return
 }
 
@@ -635,55 +794,192 @@ func SetProject(
 	project ids.ID,
 	project_name []byte,
 	project_description []byte,
-	project_owner []byte,
+	project_owner codec.Address,
 	logo []byte,
 ) error {
 
 	k := ProjectKey(project)
-
-	v := make([]byte, ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks+ProjectLogoChunks)
-
-	// saddr, _ := codec.AddressBech32(tconsts.HRP, owner)
-
-	copy(v[:ProjectNameChunks], project_name[:])
-	copy(v[ProjectNameChunks:ProjectNameChunks+ProjectDescriptionChunks], project_description[:])
-	copy(v[ProjectNameChunks+ProjectDescriptionChunks:ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks], project_owner[:])
-	copy(v[ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks:ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks+ProjectLogoChunks], logo[:])
+	v, err := encodeProject(ProjectData{
+		ProjectName:        project_name,
+		ProjectDescription: project_description,
+		ProjectOwner:       project_owner,
+		Logo:               logo,
+	})
+	if err != nil {
+		return err
+	}
 	fmt.Println("Project Added to the Chain State")
 	return mu.Insert(ctx, k, v)
 }
 
+// putProject writes [data] under [ProjectKey] and drops the legacy record of
+// [project], if any.
+func putProject(ctx context.Context, mu state.Mutable, project ids.ID, data ProjectData) error {
+	v, err := encodeProject(data)
+	if err != nil {
+		return err
+	}
+	if err := mu.Insert(ctx, ProjectKey(project), v); err != nil {
+		return err
+	}
+	return mu.Remove(ctx, LegacyProjectKey(project))
+}
+
+func GetProject(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) (bool, ProjectData, error) {
+	k, lk := ProjectKey(project), LegacyProjectKey(project)
+	v, err := im.GetValue(ctx, k)
+	lv, lerr := im.GetValue(ctx, lk)
+	return innerGetProject(k, v, err, lk, lv, lerr)
+}
+
+// Used to serve RPC queries
 func GetProjectFromState(
 	ctx context.Context,
 	f ReadState,
 	project ids.ID,
 ) (bool, ProjectData, error) {
+	k, lk := ProjectKey(project), LegacyProjectKey(project)
+	values, errs := f(ctx, [][]byte{k, lk})
+	return innerGetProject(k, values[0], errs[0], lk, values[1], errs[1])
+}
 
-	k := ProjectKey(project)
-	v, errs := f(ctx, [][]byte{k})
-
-	if errors.Is(errs[0], database.ErrNotFound) {
-		return false, ProjectData{}, nil
+func innerGetProject(
+	k []byte,
+	v []byte,
+	err error,
+	lk []byte,
+	lv []byte,
+	lerr error,
+) (bool, ProjectData, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		k, v, err = lk, lv, lerr
 	}
-	if errs[0] != nil {
+	if errors.Is(err, database.ErrNotFound) {
 		return false, ProjectData{}, nil
 	}
+	if err != nil {
+		return false, ProjectData{}, err
+	}
+	data, err := decodeProject(v)
+	if err != nil {
+		return false, ProjectData{}, err
+	}
+	data.Key = hex.EncodeToString(k)
+	return true, data, nil
+}
+
+// SetProjectMetadata replaces the description and logo of [project], the
+// name and owner are left untouched.
+func SetProjectMetadata(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	project_description []byte,
+	logo []byte,
+) error {
+	exists, data, err := GetProject(ctx, mu, project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return database.ErrNotFound
+	}
+	data.ProjectDescription = project_description
+	data.Logo = logo
+	return putProject(ctx, mu, project, data)
+}
+
+// SetProjectOwner hands [project] to [owner], the rest of the record is left
+// untouched.
+func SetProjectOwner(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	owner codec.Address,
+) error {
+	exists, data, err := GetProject(ctx, mu, project)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return database.ErrNotFound
+	}
+	data.ProjectOwner = owner
+	return putProject(ctx, mu, project, data)
+}
+
+// [pendingOwnerPrefix] + [project]
+func PendingOwnerKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = pendingOwnerPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], PendingOwnerChunks)
+	return
+}
+
+func GetPendingOwner(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) (bool, codec.Address, error) {
+	return innerGetPendingOwner(im.GetValue(ctx, PendingOwnerKey(project)))
+}
+
+// Used to serve RPC queries
+func GetPendingOwnerFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) (bool, codec.Address, error) {
+	values, errs := f(ctx, [][]byte{PendingOwnerKey(project)})
+	return innerGetPendingOwner(values[0], errs[0])
+}
+
+func innerGetPendingOwner(v []byte, err error) (bool, codec.Address, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, codec.EmptyAddress, nil
+	}
+	if err != nil {
+		return false, codec.EmptyAddress, err
+	}
+	var owner codec.Address
+	copy(owner[:], v)
+	return true, owner, nil
+}
+
+func SetPendingOwner(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	owner codec.Address,
+) error {
+	return mu.Insert(ctx, PendingOwnerKey(project), owner[:])
+}
 
-	return true, ProjectData{
-		Key:                hex.EncodeToString(k),
-		ProjectName:        v[0][:ProjectNameChunks],
-		ProjectDescription: v[0][ProjectNameChunks : ProjectNameChunks+ProjectDescriptionChunks],
-		ProjectOwner:       v[0][ProjectNameChunks+ProjectDescriptionChunks : ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks],
-		Logo:               v[0][ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks : ProjectNameChunks+ProjectDescriptionChunks+ProjectOwnerChunks+ProjectLogoChunks],
-	}, errs[0]
+func DeletePendingOwner(ctx context.Context, mu state.Mutable, project ids.ID) error {
+	return mu.Remove(ctx, PendingOwnerKey(project))
 }
 
 // [updatePrefix] + [address]
 func UpdateKey(update ids.ID) (k []byte) {
+	return updateKey(update, UpdateChunks)
+}
+
+// LegacyUpdateKey is where updates written before [UpdateChunks] are stored.
+// Callers reading an update must include it in their state keys.
+func LegacyUpdateKey(update ids.ID) (k []byte) {
+	return updateKey(update, LegacyUpdateChunks)
+}
+
+func updateKey(update ids.ID, chunks uint16) (k []byte) {
 	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
 	k[0] = updatePrefix
 	copy(k[1:], update[:])
-	binary.BigEndian.PutUint16(k[1+consts.IDLen:], UpdateExecutableHashChunks)
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], chunks)
 	return
 }
 
@@ -692,8 +988,10 @@ func UpdateKey(update ids.ID) (k []byte) {
 //	UpdateExecutableHash []byte `json:"executable_hash"`
 //	UpdateIPFSUrl       []byte `json:"executable_ipfs_url"`
 //	ForDeviceName        []byte `json:"for_device_name"`
-//	UpdateVersion        uint8  `json:"version"`
-//	SuccessCount         uint8  `json:"success_count"`
+//	UpdateVersion        SemVer `json:"version"`
+//	Channel              uint8  `json:"channel"`
+//	Signature            []byte `json:"signature"`
+//	Patch                UpdatePatch
 func SetUpdate(
 	ctx context.Context,
 	mu state.Mutable,
@@ -702,60 +1000,1291 @@ func SetUpdate(
 	executable_hash []byte,
 	executable_ipfs_url []byte,
 	for_device_name []byte,
-	version uint8,
-	success_count uint8,
+	version SemVer,
+	channel uint8,
+	signature []byte,
+	patch UpdatePatch,
+	window UpdateWindow,
 ) error {
 
 	k := UpdateKey(update)
 
-	v := make([]byte,
-		ProjectTxIDChunks+
-			UpdateExecutableHashChunks+
-			UpdateExecutableIPFSUrlChunks+
-			ForDeviceNameChunks+
-			UpdateVersionUnitsChunks+
-			SuccessCountUnitsChunks)
+	// Results are tracked under [UpdateResultsKey]
+	v, err := encodeUpdate(UpdateData{
+		ProjectTxID:          project_id,
+		UpdateExecutableHash: executable_hash,
+		UpdateIPFSUrl:        executable_ipfs_url,
+		ForDeviceName:        for_device_name,
+		UpdateVersion:        version,
+		Channel:              channel,
+		Signature:            signature,
+		UpdatePatch:          patch,
+		UpdateWindow:         window,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Update Added to the Chain State")
+	return mu.Insert(ctx, k, v)
+}
+
+// GetUpdate includes the revocation status, install results and rollout of
+// [update], so callers must also include [LegacyUpdateKey], [RevocationKey],
+// [UpdateResultsKey] and [RolloutKey] in their state keys.
+func GetUpdate(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (bool, UpdateData, error) {
+	k, lk := UpdateKey(update), LegacyUpdateKey(update)
+	v, err := im.GetValue(ctx, k)
+	lv, lerr := im.GetValue(ctx, lk)
+	rv, rerr := im.GetValue(ctx, RevocationKey(update))
+	cv, cerr := im.GetValue(ctx, UpdateResultsKey(update))
+	pv, perr := im.GetValue(ctx, RolloutKey(update))
+	return innerGetUpdate(k, v, err, lk, lv, lerr, rv, rerr, cv, cerr, pv, perr)
+}
+
+// Used to serve RPC queries
+func GetUpdateFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (bool, UpdateData, error) {
+	k, lk := UpdateKey(update), LegacyUpdateKey(update)
+	values, errs := f(ctx, [][]byte{k, lk, RevocationKey(update), UpdateResultsKey(update), RolloutKey(update)})
+	return innerGetUpdate(
+		k, values[0], errs[0],
+		lk, values[1], errs[1],
+		values[2], errs[2],
+		values[3], errs[3],
+		values[4], errs[4],
+	)
+}
+
+func innerGetUpdate(
+	k []byte,
+	v []byte,
+	err error,
+	lk []byte,
+	lv []byte,
+	lerr error,
+	rv []byte,
+	rerr error,
+	cv []byte,
+	cerr error,
+	pv []byte,
+	perr error,
+) (bool, UpdateData, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		k, v, err = lk, lv, lerr
+	}
+	if errors.Is(err, database.ErrNotFound) {
+		return false, UpdateData{}, nil
+	}
+	if err != nil {
+		return false, UpdateData{}, err
+	}
+
+	data, err := decodeUpdate(v)
+	if err != nil {
+		return false, UpdateData{}, err
+	}
+	data.Key = hex.EncodeToString(k)
+
+	switch {
+	case errors.Is(cerr, database.ErrNotFound):
+		// Nothing reported yet, records created before results were tracked
+		// may still carry a success count.
+	case cerr != nil:
+		return false, UpdateData{}, cerr
+	default:
+		data.SuccessCount = binary.BigEndian.Uint64(cv)
+		data.FailureCount = binary.BigEndian.Uint64(cv[consts.Uint64Len:])
+	}
+
+	switch {
+	case errors.Is(rerr, database.ErrNotFound):
+	case rerr != nil:
+		return false, UpdateData{}, rerr
+	default:
+		noteLen := int(binary.BigEndian.Uint16(rv[consts.Uint8Len+consts.Uint64Len:]))
+		data.Revoked = true
+		data.RevokeReason = rv[0]
+		data.RevokedAt = int64(binary.BigEndian.Uint64(rv[consts.Uint8Len:]))
+		data.RevokeNote = rv[consts.Uint8Len+consts.Uint64Len+consts.Uint16Len:][:noteLen]
+	}
 
-	// saddr, _ := codec.AddressBech32(tconsts.HRP, owner)
+	switch {
+	case errors.Is(perr, database.ErrNotFound):
+		data.RolloutPercentage = MaxRolloutPercentage
+	case perr != nil:
+		return false, UpdateData{}, perr
+	default:
+		data.RolloutPercentage = pv[0]
+	}
+	return true, data, nil
+}
 
-	copy(v[:ProjectTxIDChunks], project_id[:])
+// [rolloutPrefix] + [update]
+func RolloutKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = rolloutPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], RolloutChunks)
+	return
+}
 
-	copy(v[ProjectTxIDChunks:ProjectTxIDChunks+UpdateExecutableHashChunks], executable_hash[:])
+func SetRollout(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	percentage uint8,
+) error {
+	if percentage > MaxRolloutPercentage {
+		return ErrRolloutPercentageInvalid
+	}
+	return mu.Insert(ctx, RolloutKey(update), []byte{percentage})
+}
 
-	copy(v[ProjectTxIDChunks+UpdateExecutableHashChunks:ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks], executable_ipfs_url[:])
+// [revocationPrefix] + [update]
+func RevocationKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = revocationPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], RevocationChunks)
+	return
+}
 
-	copy(v[ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks:ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks], for_device_name[:])
+// SetUpdateChannel moves [update] to [channel]. Legacy records are migrated to
+// the current layout and key on the way, a success count they still hold is
+// moved to [UpdateResultsKey], which callers must include in their state keys.
+func SetUpdateChannel(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	channel uint8,
+) error {
+	k := UpdateKey(update)
+	v, err := mu.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		v, err = mu.GetValue(ctx, LegacyUpdateKey(update))
+	}
+	if err != nil {
+		return err
+	}
+	data, err := decodeUpdate(v)
+	if err != nil {
+		return err
+	}
+	if data.SuccessCount > 0 {
+		_, err := mu.GetValue(ctx, UpdateResultsKey(update))
+		switch {
+		case errors.Is(err, database.ErrNotFound):
+			if err := SetUpdateResults(ctx, mu, update, data.SuccessCount, 0); err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		}
+	}
+	data.Channel = channel
+	if v, err = encodeUpdate(data); err != nil {
+		return err
+	}
+	if err := mu.Insert(ctx, k, v); err != nil {
+		return err
+	}
+	return mu.Remove(ctx, LegacyUpdateKey(update))
+}
 
-	v[ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks] = version
-	v[ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks+UpdateVersionUnitsChunks] = success_count
+// [updateResultsPrefix] + [update]
+func UpdateResultsKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = updateResultsPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], UpdateResultsChunks)
+	return
+}
 
-	fmt.Println("Update Added to the Chain State")
+func SetUpdateResults(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	success uint64,
+	failure uint64,
+) error {
+	k := UpdateResultsKey(update)
+	v := make([]byte, consts.Uint64Len*2)
+	binary.BigEndian.PutUint64(v, success)
+	binary.BigEndian.PutUint64(v[consts.Uint64Len:], failure)
 	return mu.Insert(ctx, k, v)
 }
 
-func GetUpdateFromState(
+// SetRevocation marks [update] as revoked. Revocations are permanent, there
+// is no way to reinstate an update.
+func SetRevocation(
 	ctx context.Context,
-	f ReadState,
+	mu state.Mutable,
 	update ids.ID,
-) (bool, UpdateData, error) {
+	reason uint8,
+	timestamp int64,
+	note []byte,
+) error {
+	if len(note) > MaxRevocationNoteLen {
+		return ErrRevocationNoteTooLong
+	}
+	k := RevocationKey(update)
+	v := make([]byte, consts.Uint8Len+consts.Uint64Len+consts.Uint16Len+len(note))
+	v[0] = reason
+	binary.BigEndian.PutUint64(v[consts.Uint8Len:], uint64(timestamp))
+	binary.BigEndian.PutUint16(v[consts.Uint8Len+consts.Uint64Len:], uint16(len(note)))
+	copy(v[consts.Uint8Len+consts.Uint64Len+consts.Uint16Len:], note)
+	return mu.Insert(ctx, k, v)
+}
 
-	k := UpdateKey(update)
-	v, errs := f(ctx, [][]byte{k})
+// [maintainersPrefix] + [project]
+func MaintainersKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = maintainersPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], MaintainersChunks)
+	return
+}
 
-	if errors.Is(errs[0], database.ErrNotFound) {
-		return false, UpdateData{}, nil
+func GetMaintainers(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) ([]Maintainer, error) {
+	k := MaintainersKey(project)
+	return innerGetMaintainers(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetMaintainersFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) ([]Maintainer, error) {
+	values, errs := f(ctx, [][]byte{MaintainersKey(project)})
+	return innerGetMaintainers(values[0], errs[0])
+}
+
+func innerGetMaintainers(v []byte, err error) ([]Maintainer, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
 	}
-	if errs[0] != nil {
-		return false, UpdateData{}, nil
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.BigEndian.Uint16(v))
+	maintainers := make([]Maintainer, count)
+	for i := 0; i < count; i++ {
+		offset := consts.Uint16Len + i*(codec.AddressLen+consts.Uint8Len)
+		copy(maintainers[i].Address[:], v[offset:])
+		maintainers[i].Roles = v[offset+codec.AddressLen]
+	}
+	return maintainers, nil
+}
+
+func SetMaintainers(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	maintainers []Maintainer,
+) error {
+	k := MaintainersKey(project)
+	if len(maintainers) == 0 {
+		// Don't keep an empty set around, it would only cost storage.
+		return mu.Remove(ctx, k)
+	}
+	if len(maintainers) > MaxProjectMaintainers {
+		return ErrTooManyMaintainers
+	}
+	v := make([]byte, consts.Uint16Len+len(maintainers)*(codec.AddressLen+consts.Uint8Len))
+	binary.BigEndian.PutUint16(v, uint16(len(maintainers)))
+	for i, m := range maintainers {
+		offset := consts.Uint16Len + i*(codec.AddressLen+consts.Uint8Len)
+		copy(v[offset:], m.Address[:])
+		v[offset+codec.AddressLen] = m.Roles
 	}
+	return mu.Insert(ctx, k, v)
+}
+
+// [signingKeysPrefix] + [project]
+func SigningKeysKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = signingKeysPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], SigningKeysChunks)
+	return
+}
+
+func GetSigningKeys(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) ([]ed25519.PublicKey, error) {
+	k := SigningKeysKey(project)
+	return innerGetSigningKeys(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetSigningKeysFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) ([]ed25519.PublicKey, error) {
+	values, errs := f(ctx, [][]byte{SigningKeysKey(project)})
+	return innerGetSigningKeys(values[0], errs[0])
+}
 
-	return true, UpdateData{
-		Key:                  hex.EncodeToString(k),
-		ProjectTxID:          v[0][:ProjectTxIDChunks],
-		UpdateExecutableHash: v[0][ProjectTxIDChunks : ProjectTxIDChunks+UpdateExecutableHashChunks],
-		UpdateIPFSUrl:        v[0][ProjectTxIDChunks+UpdateExecutableHashChunks : ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks],
-		ForDeviceName:        v[0][ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks : ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks],
-		UpdateVersion:        v[0][ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks],
-		SuccessCount:         v[0][ProjectTxIDChunks+UpdateExecutableHashChunks+UpdateExecutableIPFSUrlChunks+ForDeviceNameChunks+UpdateVersionUnitsChunks],
-	}, errs[0]
+func innerGetSigningKeys(v []byte, err error) ([]ed25519.PublicKey, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.BigEndian.Uint16(v))
+	keys := make([]ed25519.PublicKey, count)
+	for i := 0; i < count; i++ {
+		copy(keys[i][:], v[consts.Uint16Len+i*ed25519.PublicKeyLen:])
+	}
+	return keys, nil
+}
+
+func SetSigningKeys(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	keys []ed25519.PublicKey,
+) error {
+	k := SigningKeysKey(project)
+	if len(keys) == 0 {
+		return mu.Remove(ctx, k)
+	}
+	if len(keys) > MaxProjectSigningKeys {
+		return ErrTooManySigningKeys
+	}
+	v := make([]byte, consts.Uint16Len+len(keys)*ed25519.PublicKeyLen)
+	binary.BigEndian.PutUint16(v, uint16(len(keys)))
+	for i, key := range keys {
+		copy(v[consts.Uint16Len+i*ed25519.PublicKeyLen:], key[:])
+	}
+	return mu.Insert(ctx, k, v)
+}
+
+// [latestUpdatePrefix] + [project] + [sha256(device)] + [channel]
+//
+// Device names are free text, so they are hashed to keep the key fixed size.
+// The stable channel (0) omits [channel] so pointers written before channels
+// existed still resolve.
+func LatestUpdateKey(project ids.ID, device []byte, channel uint8) (k []byte) {
+	deviceID := utils.ToID(device)
+	l := 1 + consts.IDLen*2
+	if channel != 0 {
+		l += consts.Uint8Len
+	}
+	k = make([]byte, l+consts.Uint16Len)
+	k[0] = latestUpdatePrefix
+	copy(k[1:], project[:])
+	copy(k[1+consts.IDLen:], deviceID[:])
+	if channel != 0 {
+		k[1+consts.IDLen*2] = channel
+	}
+	binary.BigEndian.PutUint16(k[l:], LatestUpdateChunks)
+	return
+}
+
+// GetLatestUpdate returns the latest update of [channel] and its version.
+// The update is [ids.Empty] if it moved to another channel.
+func GetLatestUpdate(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+) (bool, ids.ID, SemVer, error) {
+	k := LatestUpdateKey(project, device, channel)
+	return innerGetLatestUpdate(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetLatestUpdateFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+) (bool, ids.ID, SemVer, error) {
+	values, errs := f(ctx, [][]byte{LatestUpdateKey(project, device, channel)})
+	return innerGetLatestUpdate(values[0], errs[0])
+}
+
+func innerGetLatestUpdate(v []byte, err error) (bool, ids.ID, SemVer, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, SemVer{}, nil
+	}
+	if err != nil {
+		return false, ids.Empty, SemVer{}, err
+	}
+	var update ids.ID
+	copy(update[:], v[:consts.IDLen])
+	if len(v) == consts.IDLen+consts.Uint8Len {
+		return true, update, LegacyVersion(v[consts.IDLen]), nil
+	}
+	version, err := decodeSemVer(v[consts.IDLen:])
+	if err != nil {
+		return false, ids.Empty, SemVer{}, err
+	}
+	return true, update, version, nil
+}
+
+func SetLatestUpdate(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+	update ids.ID,
+	version SemVer,
+) error {
+	k := LatestUpdateKey(project, device, channel)
+	v := make([]byte, consts.IDLen+semVerLen(version))
+	copy(v, update[:])
+	encodeSemVer(v[consts.IDLen:], version)
+	return mu.Insert(ctx, k, v)
+}
+
+// ClearLatestUpdate leaves [channel] without a latest update. [version] is
+// kept so the channel still only moves forward.
+func ClearLatestUpdate(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	device []byte,
+	channel uint8,
+	version SemVer,
+) error {
+	return SetLatestUpdate(ctx, mu, project, device, channel, ids.Empty, version)
+}
+
+// DeviceID is the identifier a device is registered under, it is derived from
+// the device key so a key can only ever be bound once.
+func DeviceID(pk ed25519.PublicKey) ids.ID {
+	return utils.ToID(pk[:])
+}
+
+// [devicePrefix] + [device]
+func DeviceKey(device ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = devicePrefix
+	copy(k[1:], device[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], DeviceChunks)
+	return
+}
+
+func GetDevice(
+	ctx context.Context,
+	im state.Immutable,
+	device ids.ID,
+) (bool, DeviceData, error) {
+	v, err := im.GetValue(ctx, DeviceKey(device))
+	return innerGetDevice(device, v, err)
+}
+
+// Used to serve RPC queries
+func GetDeviceFromState(
+	ctx context.Context,
+	f ReadState,
+	device ids.ID,
+) (bool, DeviceData, error) {
+	values, errs := f(ctx, [][]byte{DeviceKey(device)})
+	return innerGetDevice(device, values[0], errs[0])
+}
+
+func innerGetDevice(device ids.ID, v []byte, err error) (bool, DeviceData, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, DeviceData{}, nil
+	}
+	if err != nil {
+		return false, DeviceData{}, err
+	}
+	var data DeviceData
+	data.ID = device
+	copy(data.Project[:], v)
+	copy(data.PublicKey[:], v[consts.IDLen:])
+	offset := consts.IDLen + ed25519.PublicKeyLen
+	data.Status = v[offset]
+	data.RegisteredAt = int64(binary.BigEndian.Uint64(v[offset+consts.Uint8Len:]))
+	data.UpdatedAt = int64(binary.BigEndian.Uint64(v[offset+consts.Uint8Len+consts.Uint64Len:]))
+	offset += consts.Uint8Len + consts.Uint64Len*2
+	modelLen := int(v[offset])
+	data.Model = v[offset+consts.Uint8Len : offset+consts.Uint8Len+modelLen]
+	data.Version, err = decodeSemVer(v[offset+consts.Uint8Len+modelLen:])
+	if err != nil {
+		return false, DeviceData{}, err
+	}
+	return true, data, nil
+}
+
+func SetDevice(
+	ctx context.Context,
+	mu state.Mutable,
+	data DeviceData,
+) error {
+	if len(data.Model) > MaxDeviceModelLen {
+		return ErrDeviceModelTooLong
+	}
+	v := make([]byte, consts.IDLen+ed25519.PublicKeyLen+consts.Uint8Len+consts.Uint64Len*2+consts.Uint8Len+len(data.Model)+semVerLen(data.Version))
+	copy(v, data.Project[:])
+	copy(v[consts.IDLen:], data.PublicKey[:])
+	offset := consts.IDLen + ed25519.PublicKeyLen
+	v[offset] = data.Status
+	binary.BigEndian.PutUint64(v[offset+consts.Uint8Len:], uint64(data.RegisteredAt))
+	binary.BigEndian.PutUint64(v[offset+consts.Uint8Len+consts.Uint64Len:], uint64(data.UpdatedAt))
+	offset += consts.Uint8Len + consts.Uint64Len*2
+	v[offset] = uint8(len(data.Model))
+	copy(v[offset+consts.Uint8Len:], data.Model)
+	encodeSemVer(v[offset+consts.Uint8Len+len(data.Model):], data.Version)
+	return mu.Insert(ctx, DeviceKey(data.ID), v)
+}
+
+// [updateReportPrefix] + [update] + [device]
+func UpdateReportKey(update ids.ID, device ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2+consts.Uint16Len)
+	k[0] = updateReportPrefix
+	copy(k[1:], update[:])
+	copy(k[1+consts.IDLen:], device[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen*2:], UpdateReportChunks)
+	return
+}
+
+// HasUpdateReport returns whether [device] already reported the result of
+// installing [update].
+func HasUpdateReport(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+	device ids.ID,
+) (bool, error) {
+	_, err := im.GetValue(ctx, UpdateReportKey(update, device))
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func SetUpdateReport(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	device ids.ID,
+	status uint8,
+	errorCode uint32,
+) error {
+	v := make([]byte, consts.Uint8Len+consts.Uint32Len)
+	v[0] = status
+	binary.BigEndian.PutUint32(v[consts.Uint8Len:], errorCode)
+	return mu.Insert(ctx, UpdateReportKey(update, device), v)
+}
+
+// [projectDevicePrefix] + [project] + [device]
+func ProjectDeviceKey(project ids.ID, device ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2)
+	k[0] = projectDevicePrefix
+	copy(k[1:], project[:])
+	copy(k[1+consts.IDLen:], device[:])
+	return
+}
+
+// StoreProjectDevice indexes [device] under [project] so the fleet of a
+// project can be listed without scanning state.
+func StoreProjectDevice(
+	_ context.Context,
+	db database.KeyValueWriter,
+	project ids.ID,
+	device ids.ID,
+) error {
+	return db.Put(ProjectDeviceKey(project, device), nil)
+}
+
+// GetProjectDevices returns up to [limit] devices of [project] in ID order,
+// starting at [start]. If there are more devices, the first device of the next
+// page is returned as well (otherwise [ids.Empty]).
+func GetProjectDevices(
+	_ context.Context,
+	db database.Iteratee,
+	project ids.ID,
+	start ids.ID,
+	limit int,
+) ([]ids.ID, ids.ID, error) {
+	prefix := ProjectDeviceKey(project, ids.Empty)[:1+consts.IDLen]
+	iter := db.NewIteratorWithStartAndPrefix(ProjectDeviceKey(project, start), prefix)
+	defer iter.Release()
+
+	devices := []ids.ID{}
+	for iter.Next() {
+		var device ids.ID
+		copy(device[:], iter.Key()[1+consts.IDLen:])
+		if len(devices) == limit {
+			return devices, device, iter.Error()
+		}
+		devices = append(devices, device)
+	}
+	return devices, ids.Empty, iter.Error()
+}
+
+// [projectHistoryPrefix] + [project] + [timestamp] + [txID]
+func ProjectHistoryKey(project ids.ID, timestamp int64, txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len+consts.IDLen)
+	k[0] = projectHistoryPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], uint64(timestamp))
+	copy(k[1+consts.IDLen+consts.Uint64Len:], txID[:])
+	return
+}
+
+// StoreProjectRevision appends [revision] to the metadata history of
+// [project]. Revisions are never rewritten.
+func StoreProjectRevision(
+	_ context.Context,
+	db database.KeyValueWriter,
+	project ids.ID,
+	revision ProjectRevision,
+) error {
+	k := ProjectHistoryKey(project, revision.Timestamp, revision.TxID)
+	v := make([]byte, consts.Uint16Len*2+len(revision.Description)+len(revision.Logo))
+	binary.BigEndian.PutUint16(v, uint16(len(revision.Description)))
+	copy(v[consts.Uint16Len:], revision.Description)
+	offset := consts.Uint16Len + len(revision.Description)
+	binary.BigEndian.PutUint16(v[offset:], uint16(len(revision.Logo)))
+	copy(v[offset+consts.Uint16Len:], revision.Logo)
+	return db.Put(k, v)
+}
+
+// GetProjectHistory returns the last [limit] revisions of [project], oldest
+// first.
+func GetProjectHistory(
+	_ context.Context,
+	db database.Iteratee,
+	project ids.ID,
+	limit int,
+) ([]ProjectRevision, error) {
+	prefix := ProjectHistoryKey(project, 0, ids.Empty)[:1+consts.IDLen]
+	iter := db.NewIteratorWithPrefix(prefix)
+	defer iter.Release()
+
+	revisions := []ProjectRevision{}
+	for iter.Next() {
+		k, v := iter.Key(), iter.Value()
+		var revision ProjectRevision
+		revision.Timestamp = int64(binary.BigEndian.Uint64(k[1+consts.IDLen:]))
+		copy(revision.TxID[:], k[1+consts.IDLen+consts.Uint64Len:])
+		descriptionLen := int(binary.BigEndian.Uint16(v))
+		revision.Description = append([]byte{}, v[consts.Uint16Len:consts.Uint16Len+descriptionLen]...)
+		offset := consts.Uint16Len + descriptionLen
+		logoLen := int(binary.BigEndian.Uint16(v[offset:]))
+		revision.Logo = append([]byte{}, v[offset+consts.Uint16Len:offset+consts.Uint16Len+logoLen]...)
+		revisions = append(revisions, revision)
+		if len(revisions) > limit {
+			revisions = revisions[1:]
+		}
+	}
+	return revisions, iter.Error()
+}
+
+// [ownerProjectPrefix] + [owner] + [project]
+func OwnerProjectKey(owner codec.Address, project ids.ID) (k []byte) {
+	k = make([]byte, 1+codec.AddressLen+consts.IDLen)
+	k[0] = ownerProjectPrefix
+	copy(k[1:], owner[:])
+	copy(k[1+codec.AddressLen:], project[:])
+	return
+}
+
+// StoreOwnerProject indexes [project] under [owner] so the projects of an
+// account can be listed without scanning state.
+func StoreOwnerProject(
+	_ context.Context,
+	db database.KeyValueWriter,
+	owner codec.Address,
+	project ids.ID,
+) error {
+	return db.Put(OwnerProjectKey(owner, project), nil)
+}
+
+// GetOwnerProjects returns up to [limit] projects indexed under [owner] in ID
+// order, starting at [start]. If there are more projects, the first project of
+// the next page is returned as well (otherwise [ids.Empty]). Projects the
+// owner has since transferred are included, callers must check the owner.
+func GetOwnerProjects(
+	_ context.Context,
+	db database.Iteratee,
+	owner codec.Address,
+	start ids.ID,
+	limit int,
+) ([]ids.ID, ids.ID, error) {
+	prefix := OwnerProjectKey(owner, ids.Empty)[:1+codec.AddressLen]
+	iter := db.NewIteratorWithStartAndPrefix(OwnerProjectKey(owner, start), prefix)
+	defer iter.Release()
+
+	projects := []ids.ID{}
+	for iter.Next() {
+		var project ids.ID
+		copy(project[:], iter.Key()[1+codec.AddressLen:])
+		if len(projects) == limit {
+			return projects, project, iter.Error()
+		}
+		projects = append(projects, project)
+	}
+	return projects, ids.Empty, iter.Error()
+}
+
+// [projectUpdatePrefix] + [project] + [timestamp] + [update]
+func ProjectUpdateKey(project ids.ID, timestamp int64, update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len+consts.IDLen)
+	k[0] = projectUpdatePrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], uint64(timestamp))
+	copy(k[1+consts.IDLen+consts.Uint64Len:], update[:])
+	return
+}
+
+// StoreProjectUpdate indexes [update] under [project] in release order.
+func StoreProjectUpdate(
+	_ context.Context,
+	db database.KeyValueWriter,
+	project ids.ID,
+	timestamp int64,
+	update ids.ID,
+) error {
+	return db.Put(ProjectUpdateKey(project, timestamp, update), nil)
+}
+
+// GetProjectUpdates returns up to [limit] updates of [project] in release
+// order, starting at [cursor]. Use nil for the first page and the returned
+// cursor for the following ones, it is nil on the last page.
+func GetProjectUpdates(
+	_ context.Context,
+	db database.Iteratee,
+	project ids.ID,
+	cursor []byte,
+	limit int,
+) ([]ProjectUpdate, []byte, error) {
+	if len(cursor) != 0 && len(cursor) != consts.Uint64Len+consts.IDLen {
+		return nil, nil, ErrInvalidCursor
+	}
+	prefix := ProjectUpdateKey(project, 0, ids.Empty)[:1+consts.IDLen]
+	iter := db.NewIteratorWithStartAndPrefix(append(prefix[:len(prefix):len(prefix)], cursor...), prefix)
+	defer iter.Release()
+
+	updates := []ProjectUpdate{}
+	for iter.Next() {
+		k := iter.Key()
+		if len(updates) == limit {
+			return updates, append([]byte{}, k[1+consts.IDLen:]...), iter.Error()
+		}
+		var update ProjectUpdate
+		update.Timestamp = int64(binary.BigEndian.Uint64(k[1+consts.IDLen:]))
+		copy(update.ID[:], k[1+consts.IDLen+consts.Uint64Len:])
+		updates = append(updates, update)
+	}
+	return updates, nil, iter.Error()
+}
+
+// [manifestPrefix] + [update]
+func ManifestKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = manifestPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ManifestChunks)
+	return
+}
+
+func GetManifest(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (Manifest, error) {
+	k := ManifestKey(update)
+	return innerGetManifest(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetManifestFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (Manifest, error) {
+	values, errs := f(ctx, [][]byte{ManifestKey(update)})
+	return innerGetManifest(values[0], errs[0])
+}
+
+func innerGetManifest(v []byte, err error) (Manifest, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeManifest(v)
+}
+
+func SetManifest(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	manifest Manifest,
+) error {
+	if err := manifest.Validate(); err != nil {
+		return err
+	}
+	v, err := encodeManifest(manifest)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ManifestKey(update), v)
+}
+
+// [constraintsPrefix] + [update]
+func ConstraintsKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = constraintsPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ConstraintsChunks)
+	return
+}
+
+func GetConstraints(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (UpdateConstraints, error) {
+	k := ConstraintsKey(update)
+	return innerGetConstraints(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetConstraintsFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (UpdateConstraints, error) {
+	values, errs := f(ctx, [][]byte{ConstraintsKey(update)})
+	return innerGetConstraints(values[0], errs[0])
+}
+
+func innerGetConstraints(v []byte, err error) (UpdateConstraints, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return UpdateConstraints{}, nil
+	}
+	if err != nil {
+		return UpdateConstraints{}, err
+	}
+	return decodeConstraints(v)
+}
+
+func SetConstraints(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	constraints UpdateConstraints,
+) error {
+	if err := constraints.Validate(); err != nil {
+		return err
+	}
+	v, err := encodeConstraints(constraints)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ConstraintsKey(update), v)
+}
+
+// [thresholdPrefix] + [project]
+func ApprovalThresholdKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = thresholdPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ThresholdChunks)
+	return
+}
+
+func GetApprovalThreshold(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) (uint8, error) {
+	k := ApprovalThresholdKey(project)
+	return innerGetApprovalThreshold(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetApprovalThresholdFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) (uint8, error) {
+	values, errs := f(ctx, [][]byte{ApprovalThresholdKey(project)})
+	return innerGetApprovalThreshold(values[0], errs[0])
+}
+
+func innerGetApprovalThreshold(v []byte, err error) (uint8, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return v[0], nil
+}
+
+func SetApprovalThreshold(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	threshold uint8,
+) error {
+	k := ApprovalThresholdKey(project)
+	if threshold == 0 {
+		return mu.Remove(ctx, k)
+	}
+	if threshold > MaxApprovalThreshold {
+		return ErrApprovalThresholdInvalid
+	}
+	return mu.Insert(ctx, k, []byte{threshold})
+}
+
+// [approvalsPrefix] + [update]
+func ApprovalsKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = approvalsPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ApprovalsChunks)
+	return
+}
+
+func GetApprovals(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (bool, UpdateApprovals, error) {
+	k := ApprovalsKey(update)
+	return innerGetApprovals(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetApprovalsFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (bool, UpdateApprovals, error) {
+	values, errs := f(ctx, [][]byte{ApprovalsKey(update)})
+	return innerGetApprovals(values[0], errs[0])
+}
+
+func innerGetApprovals(v []byte, err error) (bool, UpdateApprovals, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, UpdateApprovals{}, nil
+	}
+	if err != nil {
+		return false, UpdateApprovals{}, err
+	}
+	approvals, err := decodeApprovals(v)
+	if err != nil {
+		return false, UpdateApprovals{}, err
+	}
+	return true, approvals, nil
+}
+
+func SetApprovals(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	approvals UpdateApprovals,
+) error {
+	if approvals.Threshold == 0 || approvals.Threshold > MaxApprovalThreshold || len(approvals.Approvals) > int(approvals.Threshold) {
+		return ErrApprovalThresholdInvalid
+	}
+	v, err := encodeApprovals(approvals)
+	if err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ApprovalsKey(update), v)
+}
+
+// [licenseAssetPrefix] + [project]
+func LicenseAssetKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = licenseAssetPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], LicenseAssetChunks)
+	return
+}
+
+func GetLicenseAsset(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) (ids.ID, error) {
+	k := LicenseAssetKey(project)
+	return innerGetLicenseAsset(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetLicenseAssetFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) (ids.ID, error) {
+	values, errs := f(ctx, [][]byte{LicenseAssetKey(project)})
+	return innerGetLicenseAsset(values[0], errs[0])
+}
+
+func innerGetLicenseAsset(v []byte, err error) (ids.ID, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return ids.Empty, nil
+	}
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ID(v[:consts.IDLen]), nil
+}
+
+func SetLicenseAsset(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	asset ids.ID,
+) error {
+	k := LicenseAssetKey(project)
+	if asset == ids.Empty {
+		return mu.Remove(ctx, k)
+	}
+	return mu.Insert(ctx, k, asset[:])
+}
+
+// [updateLicensePrefix] + [update]
+func UpdateLicenseKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = updateLicensePrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], UpdateLicenseChunks)
+	return
+}
+
+func GetUpdateLicense(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (UpdateLicense, error) {
+	k := UpdateLicenseKey(update)
+	return innerGetUpdateLicense(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetUpdateLicenseFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (UpdateLicense, error) {
+	values, errs := f(ctx, [][]byte{UpdateLicenseKey(update)})
+	return innerGetUpdateLicense(values[0], errs[0])
+}
+
+func innerGetUpdateLicense(v []byte, err error) (UpdateLicense, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return UpdateLicense{}, nil
+	}
+	if err != nil {
+		return UpdateLicense{}, err
+	}
+	return UpdateLicense{
+		Asset:      ids.ID(v[:consts.IDLen]),
+		MinBalance: binary.BigEndian.Uint64(v[consts.IDLen:]),
+	}, nil
+}
+
+func SetUpdateLicense(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	license UpdateLicense,
+) error {
+	v := make([]byte, consts.IDLen+consts.Uint64Len)
+	copy(v, license.Asset[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen:], license.MinBalance)
+	return mu.Insert(ctx, UpdateLicenseKey(update), v)
+}
+
+// [updateOriginPrefix] + [update]
+func UpdateOriginKey(update ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = updateOriginPrefix
+	copy(k[1:], update[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], UpdateOriginChunks)
+	return
+}
+
+func GetUpdateOrigin(
+	ctx context.Context,
+	im state.Immutable,
+	update ids.ID,
+) (UpdateOrigin, error) {
+	k := UpdateOriginKey(update)
+	return innerGetUpdateOrigin(im.GetValue(ctx, k))
+}
+
+// Used to serve RPC queries
+func GetUpdateOriginFromState(
+	ctx context.Context,
+	f ReadState,
+	update ids.ID,
+) (UpdateOrigin, error) {
+	values, errs := f(ctx, [][]byte{UpdateOriginKey(update)})
+	return innerGetUpdateOrigin(values[0], errs[0])
+}
+
+func innerGetUpdateOrigin(v []byte, err error) (UpdateOrigin, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return UpdateOrigin{}, nil
+	}
+	if err != nil {
+		return UpdateOrigin{}, err
+	}
+	return UpdateOrigin{
+		SourceChainID: ids.ID(v[:consts.IDLen]),
+		SourceTxID:    ids.ID(v[consts.IDLen : consts.IDLen*2]),
+		SourceProject: ids.ID(v[consts.IDLen*2:]),
+	}, nil
+}
+
+// SetUpdateOrigin records where an imported [update] was first published and
+// indexes it under that origin, so the same release is only imported once.
+func SetUpdateOrigin(
+	ctx context.Context,
+	mu state.Mutable,
+	update ids.ID,
+	origin UpdateOrigin,
+) error {
+	v := make([]byte, consts.IDLen*3)
+	copy(v, origin.SourceChainID[:])
+	copy(v[consts.IDLen:], origin.SourceTxID[:])
+	copy(v[consts.IDLen*2:], origin.SourceProject[:])
+	if err := mu.Insert(ctx, UpdateOriginKey(update), v); err != nil {
+		return err
+	}
+	return mu.Insert(ctx, ImportedUpdateKey(origin), update[:])
+}
+
+// [importedPrefix] + [sourceChainID] + [sourceTxID]
+func ImportedUpdateKey(origin UpdateOrigin) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2+consts.Uint16Len)
+	k[0] = importedPrefix
+	copy(k[1:], origin.SourceChainID[:])
+	copy(k[1+consts.IDLen:], origin.SourceTxID[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen*2:], ImportedUpdateChunks)
+	return
+}
+
+// GetImportedUpdate returns the local update [origin] was imported as.
+func GetImportedUpdate(
+	ctx context.Context,
+	im state.Immutable,
+	origin UpdateOrigin,
+) (bool, ids.ID, error) {
+	return innerGetImportedUpdate(im.GetValue(ctx, ImportedUpdateKey(origin)))
+}
+
+// Used to serve RPC queries
+func GetImportedUpdateFromState(
+	ctx context.Context,
+	f ReadState,
+	origin UpdateOrigin,
+) (bool, ids.ID, error) {
+	values, errs := f(ctx, [][]byte{ImportedUpdateKey(origin)})
+	return innerGetImportedUpdate(values[0], errs[0])
+}
+
+func innerGetImportedUpdate(v []byte, err error) (bool, ids.ID, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, nil
+	}
+	if err != nil {
+		return false, ids.Empty, err
+	}
+	return true, ids.ID(v[:consts.IDLen]), nil
+}
+
+// [importSourcesPrefix] + [project]
+func ImportSourcesKey(project ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint16Len)
+	k[0] = importSourcesPrefix
+	copy(k[1:], project[:])
+	binary.BigEndian.PutUint16(k[1+consts.IDLen:], ImportSourcesChunks)
+	return
+}
+
+func GetImportSources(
+	ctx context.Context,
+	im state.Immutable,
+	project ids.ID,
+) ([]ImportSource, error) {
+	return innerGetImportSources(im.GetValue(ctx, ImportSourcesKey(project)))
+}
+
+// Used to serve RPC queries
+func GetImportSourcesFromState(
+	ctx context.Context,
+	f ReadState,
+	project ids.ID,
+) ([]ImportSource, error) {
+	values, errs := f(ctx, [][]byte{ImportSourcesKey(project)})
+	return innerGetImportSources(values[0], errs[0])
+}
+
+func innerGetImportSources(v []byte, err error) ([]ImportSource, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	count := int(binary.BigEndian.Uint16(v))
+	sources := make([]ImportSource, count)
+	for i := range sources {
+		offset := consts.Uint16Len + i*2*consts.IDLen
+		copy(sources[i].SourceChainID[:], v[offset:])
+		copy(sources[i].SourceProject[:], v[offset+consts.IDLen:])
+	}
+	return sources, nil
+}
+
+func SetImportSources(
+	ctx context.Context,
+	mu state.Mutable,
+	project ids.ID,
+	sources []ImportSource,
+) error {
+	k := ImportSourcesKey(project)
+	if len(sources) == 0 {
+		return mu.Remove(ctx, k)
+	}
+	if len(sources) > MaxProjectImportSources {
+		return ErrTooManyImportSources
+	}
+	v := make([]byte, consts.Uint16Len+len(sources)*2*consts.IDLen)
+	binary.BigEndian.PutUint16(v, uint16(len(sources)))
+	for i, source := range sources {
+		offset := consts.Uint16Len + i*2*consts.IDLen
+		copy(v[offset:], source.SourceChainID[:])
+		copy(v[offset+consts.IDLen:], source.SourceProject[:])
+	}
+	return mu.Insert(ctx, k, v)
 }
@@ -0,0 +1,33 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+// UpdateWindow is when an update can be installed, as block timestamps in
+// milliseconds. A zero bound leaves that side of the window open.
+type UpdateWindow struct {
+	NotBefore int64 `json:"not_before"` // embargo, first timestamp the update is offered
+	ExpiresAt int64 `json:"expires_at"` // the update is withdrawn from this timestamp
+}
+
+func (w UpdateWindow) Validate() error {
+	if w.NotBefore < 0 || w.ExpiresAt < 0 {
+		return ErrInvalidUpdateWindow
+	}
+	if w.ExpiresAt > 0 && w.ExpiresAt <= w.NotBefore {
+		return ErrInvalidUpdateWindow
+	}
+	return nil
+}
+
+// Check returns why the update can't be installed at [timestamp], or nil if
+// [timestamp] is inside the window.
+func (w UpdateWindow) Check(timestamp int64) error {
+	if timestamp < w.NotBefore {
+		return ErrUpdateNotYetValid
+	}
+	if w.ExpiresAt > 0 && timestamp >= w.ExpiresAt {
+		return ErrUpdateExpired
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2023, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package storage
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestUpdateWindowValidate(t *testing.T) {
+	tests := []struct {
+		w   UpdateWindow
+		err error
+	}{
+		{w: UpdateWindow{}},
+		{w: UpdateWindow{NotBefore: 1000}},
+		{w: UpdateWindow{ExpiresAt: 1000}},
+		{w: UpdateWindow{NotBefore: 1000, ExpiresAt: 1001}},
+		{w: UpdateWindow{NotBefore: 1000, ExpiresAt: 1000}, err: ErrInvalidUpdateWindow},
+		{w: UpdateWindow{NotBefore: 1000, ExpiresAt: 999}, err: ErrInvalidUpdateWindow},
+		{w: UpdateWindow{NotBefore: -1}, err: ErrInvalidUpdateWindow},
+		{w: UpdateWindow{ExpiresAt: -1}, err: ErrInvalidUpdateWindow},
+	}
+	for _, tt := range tests {
+		if err := tt.w.Validate(); !errors.Is(err, tt.err) {
+			t.Errorf("%+v.Validate() = %v, want %v", tt.w, err, tt.err)
+		}
+	}
+}
+
+func TestUpdateWindowCheck(t *testing.T) {
+	window := UpdateWindow{NotBefore: 1000, ExpiresAt: 2000}
+	tests := []struct {
+		name      string
+		w         UpdateWindow
+		timestamp int64
+		err       error
+	}{
+		{name: "open", w: UpdateWindow{}, timestamp: 0},
+		{name: "open at max", w: UpdateWindow{}, timestamp: math.MaxInt64},
+		{name: "before embargo", w: window, timestamp: 999, err: ErrUpdateNotYetValid},
+		{name: "embargo lifts", w: window, timestamp: 1000},
+		{name: "inside", w: window, timestamp: 1500},
+		{name: "last valid", w: window, timestamp: 1999},
+		{name: "expires", w: window, timestamp: 2000, err: ErrUpdateExpired},
+		{name: "after expiry", w: window, timestamp: 2001, err: ErrUpdateExpired},
+		{name: "no expiry", w: UpdateWindow{NotBefore: 1000}, timestamp: math.MaxInt64},
+		{name: "no embargo", w: UpdateWindow{ExpiresAt: 2000}, timestamp: 0},
+	}
+	for _, tt := range tests {
+		if err := tt.w.Check(tt.timestamp); !errors.Is(err, tt.err) {
+			t.Errorf("%s: Check(%d) = %v, want %v", tt.name, tt.timestamp, err, tt.err)
+		}
+	}
+}